@@ -0,0 +1,114 @@
+package dom
+
+import "github.com/tinywasm/fmt"
+
+// HostAttrs gives a component defined with DefineElement the attributes of
+// its host tag as signals: bind them in Render and the component follows
+// every setAttribute the page makes.
+type HostAttrs struct {
+	attrs []struct {
+		name   string
+		signal *SignalString
+	}
+	// read gives the host's current value of an attribute; nil on a
+	// HostAttrs that has no host.
+	read func(name string) (string, bool)
+}
+
+// Attr returns the signal for the host attribute name, holding the value the
+// attribute has when Attr is first called for it; "" when it is absent. Only
+// attributes listed as observed in DefineElement change afterwards — the
+// others keep that first value.
+func (h *HostAttrs) Attr(name string) *SignalString {
+	for _, a := range h.attrs {
+		if a.name == name {
+			return a.signal
+		}
+	}
+	s := NewString("")
+	if h.read != nil {
+		if v, ok := h.read(name); ok {
+			s.Set(v)
+		}
+	}
+	h.attrs = append(h.attrs, struct {
+		name   string
+		signal *SignalString
+	}{name, s})
+	return s
+}
+
+// DefineElement registers tag as a custom element, so pages that are not
+// written in Go can use a component as plain HTML:
+//
+//	dom.DefineElement("my-counter", func(a *dom.HostAttrs) dom.Component {
+//		return &Counter{start: a.Attr("start")}
+//	}, "start")
+//
+//	<my-counter start="3"></my-counter>
+//
+// Each <my-counter> in the page gets its own component from factory, mounted
+// into the tag through the normal lifecycle (Init, Render, Mounted) when the
+// tag is connected and unmounted (cleanups run) when it is removed; moving the
+// tag mounts a fresh one. The observed attributes feed the signals Attr
+// returns. tag must be a valid custom element name (see
+// validCustomElementName); an invalid one is an error here rather than an
+// exception from the browser's registry.
+//
+// The components mount on the default root. A root made with New defines its
+// own tags with its DefineElement method, so an embedded widget's tags live
+// and die with the widget's root.
+//
+// Backend: the tag is validated and nothing is registered — there is no
+// customElements registry under SSR.
+func DefineElement(tag string, factory func(attrs *HostAttrs) Component, observed ...string) error {
+	return instance.DefineElement(tag, factory, observed...)
+}
+
+// checkElementName is the validation every DefineElement runs first.
+func checkElementName(tag string) error {
+	if !validCustomElementName(tag) {
+		return fmt.Err("dom: custom element name", tag, "must start with a lower-case letter, contain a hyphen, use no upper case, spaces or symbols, and not be a reserved name")
+	}
+	return nil
+}
+
+// reservedElementNames are the hyphenated names SVG and MathML already use;
+// the HTML spec excludes them from custom element names.
+var reservedElementNames = []string{
+	"annotation-xml", "color-profile", "font-face", "font-face-src",
+	"font-face-uri", "font-face-format", "font-face-name", "missing-glyph",
+}
+
+// validCustomElementName applies the HTML spec's rule: an ASCII lower-case
+// letter first, then name characters (lower-case letters, digits, "-", ".",
+// "_" and the listed non-ASCII ranges), at least one hyphen, and not a
+// reserved name.
+func validCustomElementName(name string) bool {
+	if name == "" || name[0] < 'a' || name[0] > 'z' || !fmt.Contains(name, "-") {
+		return false
+	}
+	for _, r := range name {
+		if !isNameChar(r) {
+			return false
+		}
+	}
+	return !containsString(reservedElementNames, name)
+}
+
+// isNameChar reports whether r is a PCENChar of the custom element name
+// grammar.
+func isNameChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.', r == '_':
+		return true
+	case r == 0xB7, r >= 0xC0 && r <= 0xD6, r >= 0xD8 && r <= 0xF6,
+		r >= 0xF8 && r <= 0x37D, r >= 0x37F && r <= 0x1FFF,
+		r >= 0x200C && r <= 0x200D, r >= 0x203F && r <= 0x2040,
+		r >= 0x2070 && r <= 0x218F, r >= 0x2C00 && r <= 0x2FEF,
+		r >= 0x3001 && r <= 0xD7FF, r >= 0xF900 && r <= 0xFDCF,
+		r >= 0xFDF0 && r <= 0xFFFD, r >= 0x10000 && r <= 0xEFFFF:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,8 @@
+//go:build !wasm
+
+package dom
+
+// DefineElement validates tag; nothing is registered on the backend.
+func (d *domBackend) DefineElement(tag string, factory func(attrs *HostAttrs) Component, observed ...string) error {
+	return checkElementName(tag)
+}
@@ -0,0 +1,32 @@
+package dom
+
+import "testing"
+
+func TestDefineElementValidatesTag(t *testing.T) {
+	factory := func(*HostAttrs) Component { return NewElement("p") }
+	for _, bad := range []string{
+		"counter", "My-Counter", "", "1-x", "-x", "my tag-x", "my-tag!",
+		"font-face", "annotation-xml",
+	} {
+		if err := DefineElement(bad, factory); err == nil {
+			t.Errorf("%q is not a valid custom element name", bad)
+		}
+	}
+	for _, good := range []string{"my-counter", "x-1.2_b", "math-α"} {
+		if err := DefineElement(good, factory, "start"); err != nil {
+			t.Errorf("valid name %q rejected: %v", good, err)
+		}
+	}
+}
+
+func TestHostAttrsReturnsOneSignalPerName(t *testing.T) {
+	var h HostAttrs
+	start := h.Attr("start")
+	start.Set("3")
+	if h.Attr("start") != start || h.Attr("start").Get() != "3" {
+		t.Error("Attr must return the same signal for the same name")
+	}
+	if h.Attr("missing").Get() != "" {
+		t.Error("an absent attribute reads as empty")
+	}
+}
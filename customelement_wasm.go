@@ -0,0 +1,124 @@
+//go:build wasm
+
+package dom
+
+import (
+	"syscall/js"
+
+	"github.com/tinywasm/fmt"
+)
+
+// hosts are the connected custom elements and the component mounted in each.
+// Custom elements are one registry per page, so this is package-level too.
+var hosts []struct {
+	el    js.Value
+	comp  Component
+	attrs *HostAttrs
+}
+
+// DefineElement registers tag with components mounted on this root. The class
+// is built from js.FuncOf values instead of evaluating JS source, so it works
+// under a CSP without 'unsafe-eval'. The constructor returns
+// Reflect.construct(HTMLElement, [], ctor), which is what `class extends
+// HTMLElement` compiles to; the callbacks sit on its prototype. The functions
+// are never released: a definition lives as long as the page, and tags that
+// connect after the root is closed stay empty.
+func (d *domWasm) DefineElement(tag string, factory func(attrs *HostAttrs) Component, observed ...string) error {
+	if err := checkElementName(tag); err != nil {
+		return err
+	}
+	g := js.Global()
+	registry := g.Get("customElements")
+	if !registry.Truthy() {
+		return fmt.Errf("dom: custom elements are not supported here")
+	}
+	if registry.Call("get", tag).Truthy() {
+		return fmt.Errf("dom: custom element %s is already defined", tag)
+	}
+	htmlElement := g.Get("HTMLElement")
+
+	var ctor js.Func
+	ctor = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return g.Get("Reflect").Call("construct", htmlElement, g.Get("Array").New(), ctor.Value)
+	})
+	proto := g.Get("Object").Call("create", htmlElement.Get("prototype"))
+	proto.Set("constructor", ctor.Value)
+	ctor.Value.Set("prototype", proto)
+	g.Get("Object").Call("setPrototypeOf", ctor.Value, htmlElement)
+
+	names := g.Get("Array").New()
+	for _, name := range observed {
+		names.Call("push", name)
+	}
+	ctor.Value.Set("observedAttributes", names)
+
+	proto.Set("connectedCallback", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		connectHost(d, this, factory, observed)
+		return nil
+	}))
+	proto.Set("disconnectedCallback", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		disconnectHost(d, this)
+		return nil
+	}))
+	proto.Set("attributeChangedCallback", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		// Also called for the initial attributes, before connectedCallback:
+		// those are read at connect time, so an unknown host is skipped.
+		for _, h := range hosts {
+			if h.el.Equal(this) {
+				value := ""
+				if v := args[2]; v.Type() == js.TypeString {
+					value = v.String()
+				}
+				h.attrs.Attr(args[0].String()).Set(value)
+				break
+			}
+		}
+		return nil
+	}))
+
+	registry.Call("define", tag, ctor.Value)
+	return nil
+}
+
+// connectHost mounts a fresh component into the host tag. The tag gets an id
+// if it has none: Render mounts by parent id, like everywhere else.
+func connectHost(d *domWasm, el js.Value, factory func(*HostAttrs) Component, observed []string) {
+	if d.closed {
+		return
+	}
+	attrs := &HostAttrs{read: func(name string) (string, bool) {
+		v := el.Call("getAttribute", name)
+		return v.String(), v.Type() == js.TypeString
+	}}
+	for _, name := range observed {
+		attrs.Attr(name)
+	}
+	comp := factory(attrs)
+	if comp == nil {
+		return
+	}
+	if el.Get("id").String() == "" {
+		el.Set("id", generateID())
+	}
+	hosts = append(hosts, struct {
+		el    js.Value
+		comp  Component
+		attrs *HostAttrs
+	}{el, comp, attrs})
+	if err := d.Render(el.Get("id").String(), comp); err != nil {
+		d.Log("tinywasm/dom: mounting", el.Get("tagName").String(), err)
+	}
+}
+
+// disconnectHost unmounts the component of a removed host tag.
+func disconnectHost(d *domWasm, el js.Value) {
+	for i, h := range hosts {
+		if h.el.Equal(el) {
+			hosts = append(hosts[:i], hosts[i+1:]...)
+			if !d.closed { // Close already unmounted it
+				d.Unmount(h.comp)
+			}
+			return
+		}
+	}
+}
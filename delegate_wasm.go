@@ -0,0 +1,266 @@
+//go:build wasm
+
+package dom
+
+import (
+	"syscall/js"
+
+	"github.com/tinywasm/fmt"
+)
+
+// delegateRoots are the listeners on root nodes, one per (root node, event
+// type) for the whole page. They are not per domWasm: two roots listening on
+// the same document would each replay bubbling on their own, and a widget's
+// StopPropagation could not stop its host's handlers on the ancestors. keys
+// counts the delegation keys served, across every root; the listener is
+// removed and its js.Func released when the last one goes.
+var delegateRoots []struct {
+	root js.Value
+	typ  string
+	fn   js.Func
+	keys int
+}
+
+// delegation is the handlers of one element for one event type, in the order
+// they were added, with the node they were registered on and the root node and
+// type whose listener serves them. The key finds the entry; the node is what
+// keeps an id that comes back on a new element from reaching old handlers.
+type delegation struct {
+	key      string // "id::type", like eventFuncs
+	node     js.Value
+	root     js.Value
+	typ      string
+	handlers []delegatedHandler
+}
+
+// delegatedHandler is one delegated handler, with the number its remover
+// finds it by: several On calls share a key, and funcs do not compare.
+type delegatedHandler struct {
+	seq uint64
+	fn  func(Event)
+}
+
+// handlerSeq numbers delegated handlers across every root.
+var handlerSeq uint64
+
+// delegate records handler under eventKey and makes sure the element's root
+// node — the document, or the shadow root the element lives in — listens for
+// eventType and dispatches to it. A table with a thousand rows and three
+// handlers each costs two js.Func registrations per event type, not three
+// thousand.
+//
+// It returns the function that removes this one handler, and reports false
+// when delegation cannot reach the element and the caller must listen on it
+// directly: elements not in the page yet, and references whose id is not the
+// element's DOM id ("body", an id-less event target).
+func (d *domWasm) delegate(e *elementWasm, eventType, eventKey string, handler func(Event)) (remove func(), ok bool) {
+	if e.id == "" || !e.val.Get("isConnected").Bool() || e.val.Get("id").String() != e.id {
+		return nil, false
+	}
+	handlerSeq++
+	h := delegatedHandler{seq: handlerSeq, fn: handler}
+	remove = func() { d.undelegateOne(eventKey, h.seq) }
+	i, found := d.findDelegation(eventKey)
+	if found && !d.delegated[i].node.Equal(e.val) {
+		// The id is back on a new node — a keyed row removed and re-added,
+		// a component remounted. The old handlers closed over a node that
+		// is gone and must not run for this one.
+		d.undelegate(eventKey)
+		found = false
+	}
+	if found {
+		d.delegated[i].handlers = append(d.delegated[i].handlers, h)
+		return remove, true
+	}
+	root := e.val.Call("getRootNode")
+	d.delegated = append(d.delegated, delegation{})
+	copy(d.delegated[i+1:], d.delegated[i:])
+	d.delegated[i] = delegation{key: eventKey, node: e.val, root: root, typ: eventType, handlers: []delegatedHandler{h}}
+	retainRoot(root, eventType)
+	return remove, true
+}
+
+// findDelegation binary-searches d.delegated, which is kept sorted by key, and
+// returns where key is or would be inserted.
+func (d *domWasm) findDelegation(key string) (int, bool) {
+	lo, hi := 0, len(d.delegated)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if d.delegated[mid].key < key {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(d.delegated) && d.delegated[lo].key == key
+}
+
+// retainRoot counts one more key served by root's listener for eventType,
+// adding the listener when it is the first.
+func retainRoot(root js.Value, eventType string) {
+	for i, r := range delegateRoots {
+		if r.typ == eventType && r.root.Equal(root) {
+			delegateRoots[i].keys++
+			return
+		}
+	}
+	fn := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		dispatch(root, eventType, args[0])
+		return nil
+	})
+	// The bubble-phase listener serves events that bubble. Those that do not
+	// (focus, scroll, mouseenter, a script's new Event("input")) never reach
+	// the root that way, but every event descends through it: the capture
+	// registration catches them on the way down.
+	//
+	// Both say passive:false: browsers make document-level wheel, touchstart
+	// and touchmove listeners passive by default, and a delegated handler's
+	// PreventDefault would then be ignored without a word.
+	for _, capture := range []bool{false, true} {
+		o := js.Global().Get("Object").New()
+		o.Set("capture", capture)
+		o.Set("passive", false)
+		root.Call("addEventListener", eventType, fn, o)
+	}
+	delegateRoots = append(delegateRoots, struct {
+		root js.Value
+		typ  string
+		fn   js.Func
+		keys int
+	}{root, eventType, fn, 1})
+}
+
+// releaseRoot undoes one retainRoot, removing the listener with its last key.
+func releaseRoot(root js.Value, eventType string) {
+	for i, r := range delegateRoots {
+		if r.typ != eventType || !r.root.Equal(root) {
+			continue
+		}
+		delegateRoots[i].keys--
+		if delegateRoots[i].keys > 0 {
+			return
+		}
+		root.Call("removeEventListener", eventType, r.fn, false)
+		root.Call("removeEventListener", eventType, r.fn, true)
+		r.fn.Release()
+		delegateRoots = append(delegateRoots[:i], delegateRoots[i+1:]...)
+		return
+	}
+}
+
+// dispatch replays bubbling in Go: from the target up to the root, every
+// element with an id runs the handlers registered on that very node, innermost first, from
+// whichever root registered them. A StopPropagation ends the climb after the
+// handlers of the element that called it, as the browser does. An event that
+// does not bubble runs the target's handlers only. Listeners added directly on
+// an ancestor (with options) already ran by the time a bubbling event reaches
+// the root.
+//
+// Each element costs one binary search per root, so an event pays for the
+// depth of its target, not for every handler on the page.
+func dispatch(root js.Value, eventType string, v js.Value) {
+	bubbles := v.Get("bubbles").Bool()
+	capturing := v.Get("eventPhase").Int() == 1
+	if bubbles == capturing {
+		return // served by the other registration
+	}
+	evt := &eventWasm{Value: v}
+	for node := v.Get("target"); node.Truthy() && !node.Equal(root); node = node.Get("parentNode") {
+		if node.Get("nodeType").Int() != 1 {
+			continue
+		}
+		if id := node.Get("id").String(); id != "" {
+			key := id + "::" + eventType
+			// Snapshot the matches: a handler may re-render and clean up the list.
+			var handlers []struct {
+				dom *domWasm
+				fn  func(Event)
+			}
+			for _, d := range roots {
+				i, ok := d.findDelegation(key)
+				if !ok || !d.delegated[i].node.Equal(node) {
+					continue
+				}
+				for _, h := range d.delegated[i].handlers {
+					handlers = append(handlers, struct {
+						dom *domWasm
+						fn  func(Event)
+					}{d, h.fn})
+				}
+			}
+			evt.current = node
+			for _, h := range handlers {
+				evt.dom = h.dom
+				h.fn(evt)
+			}
+		}
+		if evt.stopped || !bubbles {
+			return
+		}
+	}
+}
+
+// undelegate drops the delegated handlers registered under key, and with them
+// the key's claim on its root listener.
+func (d *domWasm) undelegate(key string) {
+	i, ok := d.findDelegation(key)
+	if !ok {
+		return
+	}
+	h := d.delegated[i]
+	d.delegated = append(d.delegated[:i], d.delegated[i+1:]...)
+	releaseRoot(h.root, h.typ)
+}
+
+// undelegateOne drops the handler numbered seq from key, and the key itself
+// with its last handler. A handler already gone — removed twice, or cleaned up
+// with its component — is a no-op.
+func (d *domWasm) undelegateOne(key string, seq uint64) {
+	i, ok := d.findDelegation(key)
+	if !ok {
+		return
+	}
+	hs := d.delegated[i].handlers
+	for j, h := range hs {
+		if h.seq != seq {
+			continue
+		}
+		if len(hs) == 1 {
+			d.undelegate(key)
+			return
+		}
+		d.delegated[i].handlers = append(hs[:j:j], hs[j+1:]...)
+		return
+	}
+}
+
+// undelegateTree drops the delegated handlers of removed and of every element
+// with an id inside it: a keyed row taken out of a list is not unmounted, so
+// nothing else would release them.
+func (d *domWasm) undelegateTree(removed js.Value) {
+	d.undelegateNode(removed)
+	list := removed.Call("querySelectorAll", "[id]")
+	for i, n := 0, list.Get("length").Int(); i < n; i++ {
+		d.undelegateNode(list.Call("item", i))
+	}
+}
+
+// undelegateNode drops every key of node's id that was registered on node.
+// The keys of one id sit next to each other in the sorted index.
+func (d *domWasm) undelegateNode(node js.Value) {
+	id := node.Get("id").String()
+	if id == "" {
+		return
+	}
+	prefix := id + "::"
+	i, _ := d.findDelegation(prefix)
+	for i < len(d.delegated) && fmt.HasPrefix(d.delegated[i].key, prefix) {
+		h := d.delegated[i]
+		if !h.node.Equal(node) {
+			i++
+			continue
+		}
+		d.delegated = append(d.delegated[:i], d.delegated[i+1:]...)
+		releaseRoot(h.root, h.typ)
+	}
+}
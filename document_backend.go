@@ -0,0 +1,226 @@
+//go:build !wasm
+
+package dom
+
+import "github.com/tinywasm/fmt"
+
+// Document renders a complete HTML page around a component for SSR: doctype,
+// <head> (charset, title, meta, links), the mount point the client renders
+// into, the hydration payload and the bootstrap scripts — one String() call
+// instead of every server hand-assembling the same wrapper.
+//
+// The mount point is the same id the client later passes to Render, so the
+// WASM side replaces the server markup in place instead of appending a second
+// copy next to it.
+type Document struct {
+	lang    string
+	mountID string
+	body    Component
+	head    []headEntry
+	scripts []*Element
+	state   []fmt.KeyValue
+}
+
+// NewDocument starts a page whose <body> mounts body under #app.
+func NewDocument(body Component) *Document {
+	return &Document{lang: "en", mountID: "app", body: body}
+}
+
+// Lang sets <html lang>. Defaults to "en".
+func (d *Document) Lang(lang string) *Document {
+	d.lang = lang
+	return d
+}
+
+// Title sets the page <title>. A component that binds dom.Title wins over it.
+func (d *Document) Title(title string) *Document {
+	d.head = append(d.head, headEntry{kind: "title", value: title})
+	return d
+}
+
+// Meta adds <meta name content>. A component that binds dom.Meta with the same
+// name wins over it.
+func (d *Document) Meta(name, content string) *Document {
+	d.head = append(d.head, headEntry{kind: "meta", name: name, value: content})
+	return d
+}
+
+// Link adds <link rel href> (stylesheets, icons, preloads). A component that
+// binds dom.Link with the same rel replaces every static link of that rel.
+func (d *Document) Link(rel, href string) *Document {
+	d.head = append(d.head, headEntry{kind: "link", name: rel, value: href})
+	return d
+}
+
+// Script adds a <script src> at the end of <body>, after the mount point and
+// the state payload — the place for wasm_exec.js and the WASM loader, which
+// must find both already parsed when they run.
+func (d *Document) Script(src string) *Document {
+	d.scripts = append(d.scripts, NewElement("script").Attr("src", src))
+	return d
+}
+
+// MountID changes the id of the element the body is rendered into. It must
+// match the parentID the client passes to Render. Defaults to "app" — see the
+// mount point section of the architecture docs for why never "body".
+func (d *Document) MountID(id string) *Document {
+	d.mountID = id
+	return d
+}
+
+// State adds a key/value pair to the hydration payload. Signals registered
+// with TransferString/TransferBool are added automatically when the page
+// renders; a State with the same key is overridden by them.
+func (d *Document) State(key, value string) *Document {
+	d.state = setKeyValue(d.state, key, value)
+	return d
+}
+
+// String renders the whole page. The body component, and every component
+// nested in its tree, runs Init first with a server Ctx, exactly as Render
+// does on the client, so what Init registers on that Ctx (TransferString,
+// Title, Meta, BindDocumentCSSVar) is on the page. Cleanups registered on it
+// run when String returns: nothing outlives the page on the server.
+//
+// The page state travels in the Ctx, not in a global, so concurrent requests
+// each write only their own page.
+func (d *Document) String() string {
+	p := &ssrPage{}
+	defer func() {
+		for _, fn := range p.cleanups {
+			fn()
+		}
+	}()
+
+	// The body renders first: components register their head bindings while
+	// rendering, and those have to be known before <head> is written.
+	body := ""
+	if d.body != nil {
+		body = componentToHTML(d.body, p)
+	}
+
+	s := "<!doctype html><html lang='" + fmt.Convert(d.lang).EscapeAttr() + "'"
+	for _, attr := range mergeStyle(nil, docVars(p.vars)) {
+		s += " " + attr.Key + "='" + fmt.Convert(attr.Value).EscapeAttr() + "'"
+	}
+	s += "><head>"
+	s += "<meta charset='utf-8'>"
+	for _, h := range mergeHead(d.head, p.head) {
+		s += headToHTML(h)
+	}
+	s += "</head><body>"
+	s += "<div id='" + fmt.Convert(d.mountID).EscapeAttr() + "'>" + body + "</div>"
+	state := append([]fmt.KeyValue(nil), d.state...)
+	for _, t := range p.transfers {
+		state = setKeyValue(state, t.name, transferValue(t.signal))
+	}
+	if len(state) > 0 {
+		s += "<script type='application/json' id='" + statePayloadID + "'>" + statePayload(state) + "</script>"
+	}
+	for _, el := range d.scripts {
+		s += el.String()
+	}
+	s += "</body></html>"
+	return s
+}
+
+// mergeHead drops the static entries a bound entry replaces, then keeps only
+// the last bound entry per tag — the same last-mounted-wins rule WASM applies.
+func mergeHead(static, bound []headEntry) []headEntry {
+	var out []headEntry
+	for _, h := range static {
+		replaced := false
+		for _, b := range bound {
+			if b.sameTag(h) {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			out = append(out, h)
+		}
+	}
+	for i, b := range bound {
+		last := true
+		for _, later := range bound[i+1:] {
+			if later.sameTag(b) {
+				last = false
+				break
+			}
+		}
+		if last {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// ssrPage is the state of one Document render: the signals registered for
+// transfer, the head bindings, the :root custom properties (value is used when
+// signal is nil) and the Ctx cleanups of the components it initialized. It lives
+// and dies with the String call, so one page's state never reaches another.
+type ssrPage struct {
+	transfers []transfer
+	head      []headEntry
+	vars      []headEntry
+	cleanups  []func()
+}
+
+// ssrCtx is the Ctx a component's Init receives during a Document render. It
+// is also how registrations find their page.
+type ssrCtx struct{ p *ssrPage }
+
+func (c ssrCtx) OnCleanup(fn func()) {
+	c.p.cleanups = append(c.p.cleanups, fn)
+}
+
+// pageOf returns the page a Ctx belongs to, nil for any other Ctx: there is
+// no page to carry what was registered on it.
+func pageOf(ctx Ctx) *ssrPage {
+	if c, ok := ctx.(ssrCtx); ok {
+		return c.p
+	}
+	return nil
+}
+
+// componentToHTML serializes a component the way the client will first draw
+// it: Init, then a ViewRenderer through its Render tree (with the component id
+// on the root, as injectComponentID does in WASM), an element through its
+// tree, anything else through String. Nested components go through it too.
+func componentToHTML(c Component, p *ssrPage) string {
+	if in, ok := c.(initable); ok {
+		in.Init(ssrCtx{p: p})
+	}
+	nested := func(c Component) string { return componentToHTML(c, p) }
+	if vr, ok := c.(ViewRenderer); ok {
+		root := vr.Render()
+		injectComponentID(root, c.GetID())
+		return elementToHTMLWith(root, nested)
+	}
+	if en, ok := c.(elementNode); ok {
+		return elementToHTMLWith(en.AsElement(), nested)
+	}
+	if el, ok := c.(*Element); ok {
+		return elementToHTMLWith(el, nested)
+	}
+	return c.String()
+}
+
+// statePayload encodes the pairs as a flat JSON object of strings. "</" is
+// escaped as "<\/" so a value can never close the surrounding <script>.
+func statePayload(state []fmt.KeyValue) string {
+	b := fmt.GetConv()
+	b.WriteByte('{')
+	for i, kv := range state {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('"')
+		fmt.JSONEscape(kv.Key, b)
+		b.WriteString(`":"`)
+		fmt.JSONEscape(kv.Value, b)
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return fmt.ReplaceAll(b.String(), "</", `<\/`)
+}
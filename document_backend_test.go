@@ -0,0 +1,237 @@
+//go:build !wasm
+
+package dom
+
+import (
+	"strings"
+	"testing"
+)
+
+type docComp struct {
+	Element
+	label *SignalString
+}
+
+func (c *docComp) Render() *Element {
+	return NewElement("p").Child(NewElement("span").BindText(c.label))
+}
+
+func TestDocumentRendersFullPage(t *testing.T) {
+	c := &docComp{label: NewString("hello")}
+	c.SetID("root-comp")
+
+	html := NewDocument(c).
+		Lang("es").
+		Title("Tom & Jerry").
+		Meta("description", "a page").
+		Link("stylesheet", "/style.css").
+		Script("/wasm_exec.js").
+		State("count", "3").
+		String()
+
+	for _, want := range []string{
+		"<!doctype html><html lang='es'><head><meta charset='utf-8'>",
+		"<title>Tom &amp; Jerry</title>",
+		"<meta name='description' content='a page'>",
+		"<link rel='stylesheet' href='/style.css'>",
+		"<div id='app'><p id='root-comp'><span",
+		">hello</span></p></div>",
+		`<script type='application/json' id='dom-state'>{"count":"3"}</script>`,
+		"<script src='/wasm_exec.js'></script></body></html>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("missing %q in:\n%s", want, html)
+		}
+	}
+	if strings.Index(html, "dom-state") > strings.Index(html, "wasm_exec.js") {
+		t.Error("state payload must precede the bootstrap scripts that read it")
+	}
+}
+
+func TestDocumentStatePayloadCannotCloseScript(t *testing.T) {
+	html := NewDocument(nil).State("x", `</script><b>"q"`).String()
+	if strings.Contains(html, `</script><b>`) {
+		t.Errorf("payload value closed the script tag: %s", html)
+	}
+	if !strings.Contains(html, `{"x":"<\/script><b>\"q\""}`) {
+		t.Errorf("unexpected payload encoding: %s", html)
+	}
+}
+
+type transferComp struct {
+	Element
+	count *SignalString
+	open  *SignalBool
+}
+
+func (c *transferComp) Init(ctx Ctx) {
+	c.count = TransferString(ctx, "count", NewString("0"))
+	c.open = TransferBool(ctx, "open", NewBool(false))
+	c.count.Set("7")
+	c.open.Set(true)
+}
+
+func TestDocumentCarriesTransferredSignals(t *testing.T) {
+	html := NewDocument(&transferComp{}).State("count", "stale").String()
+	if !strings.Contains(html, `{"count":"7","open":"true"}`) {
+		t.Errorf("transferred signals missing from payload: %s", html)
+	}
+
+	// The registry is per page: the next document starts empty.
+	if next := NewDocument(nil).String(); strings.Contains(next, "dom-state") {
+		t.Errorf("transferred state leaked into the next page: %s", next)
+	}
+}
+
+func TestTransferOutsideDocumentIsDropped(t *testing.T) {
+	TransferString(nil, "stray", NewString("x"))
+	if html := NewDocument(nil).String(); strings.Contains(html, "stray") {
+		t.Errorf("a transfer registered outside a render reached the page: %s", html)
+	}
+}
+
+type headComp struct{ Element }
+
+func (c *headComp) Init(ctx Ctx) {
+	Title(ctx, NewString("List"))
+	Title(ctx, NewString("Detail")) // mounted last: wins
+	Meta(ctx, "description", NewString("bound"))
+	Canonical(ctx, NewString("/items/1"))
+}
+
+func TestDocumentHeadBindingsWinOverStaticHead(t *testing.T) {
+	html := NewDocument(&headComp{}).
+		Title("Static").
+		Meta("description", "static").
+		Meta("robots", "index").
+		String()
+
+	for _, want := range []string{
+		"<title>Detail</title>",
+		"<meta name='description' content='bound'>",
+		"<meta name='robots' content='index'>",
+		"<link rel='canonical' href='/items/1'>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("missing %q in:\n%s", want, html)
+		}
+	}
+	for _, gone := range []string{"Static", "List", "content='static'"} {
+		if strings.Contains(html, gone) {
+			t.Errorf("%q should have been replaced:\n%s", gone, html)
+		}
+	}
+	if next := NewDocument(nil).String(); strings.Contains(next, "<title>") {
+		t.Errorf("head bindings leaked into the next page: %s", next)
+	}
+}
+
+type themeComp struct{ Element }
+
+func (c *themeComp) Init(ctx Ctx) {
+	accent := NewString("#f00")
+	BindDocumentCSSVar(ctx, "accent", accent)
+	BindDocumentCSSVar(ctx, "--radius", NewString("4px"))
+	SetDocumentCSSVar("gap", "1rem") // no Ctx, no page: ignored on the server
+	accent.Set("#0f0")
+}
+
+func TestDocumentRendersRootCSSVars(t *testing.T) {
+	html := NewDocument(&themeComp{}).String()
+	if !strings.Contains(html, "<html lang='en' style='--accent:#0f0;--radius:4px;'><head>") {
+		t.Errorf("root custom properties missing from <html>: %s", html)
+	}
+	if next := NewDocument(nil).String(); strings.Contains(next, "style=") {
+		t.Errorf("custom properties leaked into the next page: %s", next)
+	}
+}
+
+// initComp registers its page state from Init, the way the docs tell authors
+// to, and nests a child that does the same.
+type initComp struct {
+	Element
+	count   *SignalString
+	child   *initChild
+	cleaned *bool
+}
+
+func (c *initComp) Init(ctx Ctx) {
+	c.count = TransferString(ctx, "init-count", NewString("5"))
+	Title(ctx, NewString("From Init"))
+	BindDocumentCSSVar(ctx, "tone", NewString("dark"))
+	ctx.OnCleanup(func() { *c.cleaned = true })
+}
+
+func (c *initComp) Render() *Element {
+	return NewElement("div").
+		Child(NewElement("p").BindText(c.count)).
+		Child(c.child)
+}
+
+type initChild struct {
+	Element
+	label *SignalString
+}
+
+func (c *initChild) Init(ctx Ctx) {
+	c.label = NewString("child ready")
+	Meta(ctx, "description", c.label)
+}
+
+func (c *initChild) Render() *Element {
+	return NewElement("span").BindText(c.label)
+}
+
+func TestDocumentRunsInitBeforeRendering(t *testing.T) {
+	cleaned := false
+	c := &initComp{child: &initChild{}, cleaned: &cleaned}
+
+	html := NewDocument(c).String()
+	for _, want := range []string{
+		"<title>From Init</title>",
+		"<meta name='description' content='child ready'>",
+		"style='--tone:dark;'",
+		">5</p>",
+		">child ready</span>",
+		`{"init-count":"5"}`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("missing %q in:\n%s", want, html)
+		}
+	}
+	if !cleaned {
+		t.Error("Init cleanups must run once the page is written")
+	}
+}
+
+// nestedPageComp renders a whole other Document from inside its own Init, the
+// way a second request's render interleaves with the first on a server. Each
+// page must keep only what was registered on its own Ctx.
+type nestedPageComp struct {
+	Element
+	inner *string
+}
+
+func (c *nestedPageComp) Init(ctx Ctx) {
+	Title(ctx, NewString("Outer"))
+	*c.inner = NewDocument(&transferComp{}).String()
+	TransferString(ctx, "outer", NewString("mine"))
+}
+
+func TestInterleavedDocumentsKeepTheirOwnState(t *testing.T) {
+	var inner string
+	outer := NewDocument(&nestedPageComp{inner: &inner}).String()
+
+	if !strings.Contains(outer, "<title>Outer</title>") || !strings.Contains(outer, `{"outer":"mine"}`) {
+		t.Errorf("the outer page lost its own state: %s", outer)
+	}
+	if strings.Contains(outer, `"count"`) {
+		t.Errorf("the inner page's transfers reached the outer one: %s", outer)
+	}
+	if strings.Contains(inner, "Outer") || strings.Contains(inner, `"outer"`) {
+		t.Errorf("the outer page's state reached the inner one: %s", inner)
+	}
+	if !strings.Contains(inner, `{"count":"7","open":"true"}`) {
+		t.Errorf("the inner page lost its transfers: %s", inner)
+	}
+}
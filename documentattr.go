@@ -30,3 +30,30 @@ func GetDocumentAttr(attr string) string {
 	}
 	return v.String()
 }
+
+// SetDocumentCSSVar sets a CSS custom property on document.documentElement, the
+// :root every theme token is declared on. name may omit the leading "--".
+// value=="" removes the property, as SetDocumentAttr does for attributes.
+func SetDocumentCSSVar(name, value string) {
+	html := instance.(*domWasm).document.Get("documentElement")
+	if !html.Truthy() {
+		return
+	}
+	if value == "" {
+		html.Get("style").Call("removeProperty", cssVarName(name))
+	} else {
+		html.Get("style").Call("setProperty", cssVarName(name), value)
+	}
+}
+
+// BindDocumentCSSVar keeps a :root custom property in sync with s. With the
+// Ctx from Init the binding belongs to that component and stops when it
+// unmounts (the property keeps its last value); with a nil ctx it lives as
+// long as the page.
+func BindDocumentCSSVar(ctx Ctx, name string, s *SignalString) {
+	SetDocumentCSSVar(name, s.Get())
+	unsub := s.subscribe(func() { SetDocumentCSSVar(name, s.Get()) })
+	if ctx != nil {
+		ctx.OnCleanup(unsub)
+	}
+}
@@ -2,8 +2,34 @@
 
 package dom
 
+import "github.com/tinywasm/fmt"
+
 // SetDocumentAttr is a no-op on the backend.
 func SetDocumentAttr(_, _ string) {}
 
 // GetDocumentAttr returns an empty string on the backend.
 func GetDocumentAttr(_ string) string { return "" }
+
+// SetDocumentCSSVar is a no-op on the backend: with no Ctx it cannot know the
+// page. Themes that must arrive with the HTML use BindDocumentCSSVar.
+func SetDocumentCSSVar(_, _ string) {}
+
+// BindDocumentCSSVar records the signal for the <html style> of the Document
+// ctx belongs to, so the page arrives already themed instead of flashing the
+// default; its value at render time is written. Without a page the server
+// ignores it, like the head bindings.
+func BindDocumentCSSVar(ctx Ctx, name string, s *SignalString) {
+	if p := pageOf(ctx); p != nil {
+		p.vars = append(p.vars, headEntry{kind: "cssvar", name: cssVarName(name), signal: s})
+	}
+}
+
+// docVars returns the recorded properties as style declarations, last write
+// per name winning.
+func docVars(vars []headEntry) []fmt.KeyValue {
+	var out []fmt.KeyValue
+	for _, v := range vars {
+		out = setKeyValue(out, v.name, v.get())
+	}
+	return out
+}
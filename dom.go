@@ -16,7 +16,31 @@ type tinyDOM struct {
 	devMode bool
 }
 
-// generateID creates a unique ID for a component.
+// Options configures a root made with New.
+type Options struct {
+	// Log receives the root's log output; nil discards it.
+	Log func(v ...any)
+	// DevMode turns on the root's development diagnostics (patch logging).
+	DevMode bool
+}
+
+// New returns an independent root: its own mounted components, element cache,
+// listeners, subscriptions, logger and dev-mode flag. Two apps on one page —
+// micro-frontends, a widget embedded in a host app — each render through their
+// own root, and unmounting or re-rendering one never touches the other's
+// state. Close tears the root down when its app goes away. The package
+// functions (Render, Get, ...) use a default root.
+//
+// What is one per page stays shared: the id counter (ids are DOM ids, and two
+// roots in one document minting "3" each would be the duplicate-id bug below),
+// the head, the keyboard-shortcut registry, document attributes and the
+// localStorage budget.
+func New(opts Options) DOM {
+	return newDom(&tinyDOM{log: opts.Log, devMode: opts.DevMode})
+}
+
+// generateID creates a unique ID for a component. The counter is shared by
+// every root, so ids stay unique in the document.
 func generateID() string {
 	idCounter++
 	return fmt.Sprint(idCounter)
@@ -91,6 +115,28 @@ func Append(parentID string, component Component) error {
 	return instance.Append(parentID, component)
 }
 
+// Prepend injects a component BEFORE the first child of the parent element.
+func Prepend(parentID string, component Component) error {
+	return instance.Prepend(parentID, component)
+}
+
+// InsertBefore injects a component right before the child beforeID of the
+// parent element.
+func InsertBefore(parentID, beforeID string, component Component) error {
+	return instance.InsertBefore(parentID, beforeID, component)
+}
+
+// Replace unmounts old and mounts component where old was.
+func Replace(old, component Component) error {
+	return instance.Replace(old, component)
+}
+
+// Unmount removes a component from the DOM, running its cleanups and those of
+// its children.
+func Unmount(component Component) {
+	instance.Unmount(component)
+}
+
 // Log provides logging functionality.
 func Log(v ...any) {
 	instance.Log(v...)
@@ -101,6 +147,16 @@ func Get(id string) (Reference, bool) {
 	return instance.Get(id)
 }
 
+// Query returns the first element in the document matching a CSS selector.
+func Query(selector string) (Reference, bool) {
+	return instance.Query(selector)
+}
+
+// QueryAll returns every element in the document matching a CSS selector.
+func QueryAll(selector string) []Reference {
+	return instance.QueryAll(selector)
+}
+
 // OnHashChange registers a hash change listener.
 func OnHashChange(handler func(hash string)) {
 	instance.OnHashChange(handler)
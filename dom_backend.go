@@ -17,8 +17,12 @@ func newDom(td *tinyDOM) DOM {
 }
 
 // Get retrieves an element by ID.
+// Get reports not found: there is no live node under SSR. Code that reaches
+// for an element must be ready for that on the client too (the element may
+// not be rendered yet), so the backend takes the same branch instead of
+// handing out a Reference whose every call silently does nothing.
 func (d *domBackend) Get(id string) (Reference, bool) {
-	return &elementStub{}, true
+	return nil, false
 }
 
 // Render is not implemented for backend.
@@ -35,10 +39,33 @@ func (d *domBackend) Append(parentID string, component Component) error {
 func (d *domBackend) update(id string) {
 }
 
-// unmount is not implemented for backend.
-func (d *domBackend) unmount(component Component) {
+// Prepend is not implemented for backend.
+func (d *domBackend) Prepend(parentID string, component Component) error {
+	return fmt.Errf("Prepend not supported in backend/stub")
 }
 
+// InsertBefore is not implemented for backend.
+func (d *domBackend) InsertBefore(parentID, beforeID string, component Component) error {
+	return fmt.Errf("InsertBefore not supported in backend/stub")
+}
+
+// Replace is not implemented for backend.
+func (d *domBackend) Replace(old, component Component) error {
+	return fmt.Errf("Replace not supported in backend/stub")
+}
+
+// AttachShadow is not implemented for backend.
+func (d *domBackend) AttachShadow(hostID string, opts ShadowOptions) (string, error) {
+	return "", fmt.Errf("AttachShadow not supported in backend/stub")
+}
+
+// Unmount is not implemented for backend.
+func (d *domBackend) Unmount(component Component) {
+}
+
+// Close is not implemented for backend.
+func (d *domBackend) Close() {}
+
 func (d *domBackend) OnHashChange(handler func(hash string)) {}
 
 func (d *domBackend) OnScrollCapture(handler func(scrollTop float64)) {}
@@ -48,25 +75,17 @@ func (d *domBackend) OnScrollCapture(handler func(scrollTop float64)) {}
 func Show(cond *SignalBool, content Component) *Element {
 	container := NewElement("div")
 	if !cond.Get() {
-		container.Attr("style", "display:none")
+		container.Style("display", "none")
 	}
 	container.Child(content)
 	return container
 }
+
+// Query reports not found, like Get.
+func (d *domBackend) Query(selector string) (Reference, bool) { return nil, false }
+
+// QueryAll finds nothing, like Get.
+func (d *domBackend) QueryAll(selector string) []Reference { return nil }
+
 func (d *domBackend) GetHash() string     { return "" }
 func (d *domBackend) SetHash(hash string) {}
-
-// elementStub is a no-op implementation of Reference for backend.
-type elementStub struct{}
-
-func (e *elementStub) GetAttr(key string) string                      { return "" }
-func (e *elementStub) Value() string                                  { return "" }
-func (e *elementStub) SetValue(value string)                          {}
-func (e *elementStub) SetAttr(key, value string)                      {}
-func (e *elementStub) RemoveAttr(key string)                          {}
-func (e *elementStub) SetText(text string)                            {}
-func (e *elementStub) Checked() bool                                  { return false }
-func (e *elementStub) On(eventType string, handler func(event Event)) {}
-func (e *elementStub) Focus()                                         {}
-func (e *elementStub) ScrollIntoView()                                {}
-func (e *elementStub) ScrollsX() bool                                 { return false }
@@ -10,10 +10,12 @@ func TestBackendStubs(t *testing.T) {
 	td := &tinyDOM{}
 	d := newDom(td)
 
-	if _, ok := d.(interface {
-		Get(string) (Reference, bool)
-	}).Get("test"); !ok {
-		t.Error("get should return true (stub) on backend")
+	if ref, ok := d.Get("test"); ok || ref != nil {
+		t.Error("Get must report not found on backend: there is no live node under SSR")
+	}
+
+	if ref, ok := d.Query("#test"); ok || ref != nil || len(d.QueryAll("li")) != 0 {
+		t.Error("Query/QueryAll must find nothing on backend")
 	}
 
 	if err := d.Render("p", nil); err == nil {
@@ -26,7 +28,21 @@ func TestBackendStubs(t *testing.T) {
 
 	d.(interface{ update(string) }).update("")
 
-	d.(interface{ unmount(Component) }).unmount(nil)
+	if err := d.Prepend("p", nil); err == nil {
+		t.Error("Prepend should return error on backend")
+	}
+	if err := d.InsertBefore("p", "b", nil); err == nil {
+		t.Error("InsertBefore should return error on backend")
+	}
+	if err := d.Replace(nil, nil); err == nil {
+		t.Error("Replace should return error on backend")
+	}
+
+	if _, err := d.AttachShadow("host", ShadowOptions{}); err == nil {
+		t.Error("AttachShadow should return error on backend")
+	}
+
+	d.Unmount(nil)
 	d.OnHashChange(func(h string) {})
 	if d.GetHash() != "" {
 		t.Error("GetHash should return empty string on backend")
@@ -46,3 +62,16 @@ func TestOnScrollCaptureBackendIsANoop(t *testing.T) {
 		t.Error("OnScrollCapture backend stub should never invoke the handler")
 	}
 }
+
+func TestNewRootHasItsOwnLogger(t *testing.T) {
+	var got []any
+	root := New(Options{Log: func(v ...any) { got = append(got, v...) }})
+	root.Log("from root")
+	if len(got) != 1 || got[0] != "from root" {
+		t.Errorf("root logger: %v", got)
+	}
+	Log("from default") // the default root has no logger set here
+	if len(got) != 1 {
+		t.Errorf("default root wrote to the new root's logger: %v", got)
+	}
+}
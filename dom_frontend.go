@@ -20,19 +20,26 @@ type domWasm struct {
 		val js.Value
 	}
 	eventFuncs []struct {
-		key string
-		val js.Value // The element where listener is attached
-		fn  js.Func
+		key     string
+		val     js.Value // The element where listener is attached
+		fn      js.Func
+		capture bool     // removeEventListener only matches the phase it was added in
+		abort   js.Value // AbortController of a listener added with options; undefined otherwise
 	}
 	componentListeners []struct {
 		id   string
 		keys []string
 	}
+	// Delegated handlers, sorted by key so dispatch finds an element's by
+	// binary search. The listeners that dispatch to them are per page
+	// (delegateRoots), shared by every root.
+	delegated          []delegation
 	currentComponentID string // Tracks the component being mounted
 	pendingEvents      []struct {
 		id      string
 		ownerID string
 		name    string
+		opts    ListenerOptions
 		handler func(Event)
 	}
 
@@ -59,6 +66,24 @@ type domWasm struct {
 		id   string
 		root *Element
 	}
+	// Per-node teardown (ref clearing, OnMount cleanups), keyed by the owner
+	// the node was wired for: run with the owner's unmount or re-render, or
+	// with the row that holds the node when a BindChildren list drops it.
+	detach []struct {
+		owner string
+		val   js.Value
+		fn    func()
+	}
+	// Shadow roots made by AttachShadow. Lookups by id fall back to them, so
+	// a closed root (host.shadowRoot is null) is still reachable.
+	shadows []js.Value
+	// OnMount hooks of wired elements, run once the whole pass is wired.
+	pendingMounts []struct {
+		owner string
+		ref   Reference
+		fn    func(Reference) func()
+	}
+	closed bool // Close ran: the root is out of roots and mounts nothing
 }
 
 // newDom returns a new instance of the domWasm.
@@ -75,12 +100,119 @@ func newDom(td *tinyDOM) DOM {
 			}
 		}
 	}
-	return &domWasm{
+	d := &domWasm{
 		tinyDOM:      td,
 		document:     js.Global().Get("document"),
 		localStorage: ls,
 		lsUsedBytes:  used,
 	}
+	roots = append(roots, d)
+	return d
+}
+
+// roots are every domWasm on the page, the default instance and those made
+// with New; active is the one running a render, update or insertion, so code
+// called from inside it (Show, head bindings, shortcuts registered in Init)
+// attaches its state to the right root.
+var (
+	roots  []*domWasm
+	active *domWasm
+)
+
+// current returns the root that is rendering, or the default one.
+func current() *domWasm {
+	if active != nil {
+		return active
+	}
+	return instance.(*domWasm)
+}
+
+// enter makes d the active root until the returned function runs:
+// defer d.enter()().
+func (d *domWasm) enter() func() {
+	prev := active
+	active = d
+	return func() { active = prev }
+}
+
+// Close tears down a root made with New: every component it mounted is
+// unmounted (markup removed, listeners, subscriptions and cleanups run, Ctx
+// cleanups included), whatever else it still holds is released, and it leaves
+// roots, so dispatch and lookups stop visiting it. The default root lives as
+// long as the page; Close on it does nothing.
+func (d *domWasm) Close() {
+	if d.closed || d == instance {
+		return
+	}
+	defer d.enter()()
+
+	// Top-level components are the children of mount points, the parents that
+	// are not components themselves; Unmount takes their subtrees along.
+	var top []Component
+	for _, item := range d.childrenMap {
+		if d.mounted(item.parentID) != nil {
+			continue
+		}
+		for _, childID := range item.childIDs {
+			if c := d.mounted(childID); c != nil {
+				top = append(top, c)
+			}
+		}
+	}
+	for _, c := range top {
+		d.Unmount(c)
+	}
+
+	// What no component owned: listeners on references, page-lifetime
+	// bindings, shadow roots attached outside Init, detach hooks, global
+	// shortcuts registered without a Ctx.
+	for _, ef := range d.eventFuncs {
+		if parts := d.splitEventKey(ef.key); len(parts) == 2 && ef.val.Truthy() {
+			ef.val.Call("removeEventListener", parts[1], ef.fn, ef.capture)
+		}
+		if ef.abort.Truthy() {
+			ef.abort.Call("abort")
+		}
+		ef.fn.Release()
+	}
+	for _, h := range d.delegated {
+		releaseRoot(h.root, h.typ)
+	}
+	for _, u := range d.unsubs {
+		u.unsub()
+	}
+	for _, c := range d.cleanups {
+		c.fn()
+	}
+	for _, item := range d.detach {
+		item.fn()
+	}
+	kept := shortcuts[:0]
+	for _, sc := range shortcuts {
+		if sc.dom != d {
+			kept = append(kept, sc)
+		}
+	}
+	shortcuts = kept
+
+	closed := &domWasm{tinyDOM: d.tinyDOM, document: d.document, localStorage: d.localStorage, closed: true}
+	*d = *closed
+	for i, r := range roots {
+		if r == d {
+			roots = append(roots[:i], roots[i+1:]...)
+			break
+		}
+	}
+}
+
+// mounted returns the component tracked under id, or nil.
+func (d *domWasm) mounted(id string) Component {
+	for _, item := range d.mountedComponents {
+		if item.id == id {
+			return item.comp
+		}
+	}
+	return nil
 }
 
 // lsEntrySize estimates the UTF-16 byte size of a localStorage entry.
@@ -113,7 +245,7 @@ func (d *domWasm) Get(id string) (Reference, bool) {
 	case "head":
 		val = d.document.Get("head")
 	default:
-		val = d.document.Call("getElementById", id)
+		val = d.byID(id)
 	}
 
 	if val.IsNull() || val.IsUndefined() {
@@ -134,6 +266,73 @@ func (d *domWasm) Get(id string) (Reference, bool) {
 	}, true
 }
 
+// Query returns the first element matching selector in the document.
+func (d *domWasm) Query(selector string) (Reference, bool) {
+	v := query(d.document, "querySelector", selector)
+	if !v.Truthy() {
+		return nil, false
+	}
+	return d.wrap(v), true
+}
+
+// QueryAll returns every element matching selector in the document.
+func (d *domWasm) QueryAll(selector string) []Reference {
+	return d.wrapList(query(d.document, "querySelectorAll", selector))
+}
+
+// query calls querySelector or querySelectorAll on scope. A selector the
+// browser rejects throws a SyntaxError, which would end the program; it finds
+// nothing instead, as attachShadow turns its exception into an error.
+func query(scope js.Value, method, selector string) (v js.Value) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(js.Error); !ok {
+				panic(r)
+			}
+			v = js.Null()
+		}
+	}()
+	return scope.Call(method, selector)
+}
+
+// wrap returns a Reference for a node found by navigation or selector rather
+// than by id. Its id is whatever the node carries, possibly none; On gives an
+// id-less reference a key of its own. A node with an id enters elementCache,
+// so a later Get(id) finds it there and drops it once it leaves the page.
+func (d *domWasm) wrap(v js.Value) Reference {
+	id := v.Get("id").String()
+	if id != "" {
+		cached := false
+		for _, item := range d.elementCache {
+			if item.id == id {
+				cached = item.val.Equal(v)
+				break
+			}
+		}
+		if !cached {
+			d.removeFromElementCache(id)
+			d.elementCache = append(d.elementCache, struct {
+				id  string
+				val js.Value
+			}{id, v})
+		}
+	}
+	return &elementWasm{val: v, dom: d, id: id}
+}
+
+// wrapList wraps every node of a NodeList or HTMLCollection.
+func (d *domWasm) wrapList(list js.Value) []Reference {
+	if !list.Truthy() {
+		return nil
+	}
+	n := list.Get("length").Int()
+	refs := make([]Reference, 0, n)
+	for i := 0; i < n; i++ {
+		refs = append(refs, d.wrap(list.Call("item", i)))
+	}
+	return refs
+}
+
 // getElement resolves a parentID to a js.Value, handling special cases like "body" and "head".
 func (d *domWasm) getElement(id string) js.Value {
 	switch id {
@@ -142,8 +341,50 @@ func (d *domWasm) getElement(id string) js.Value {
 	case "head":
 		return d.document.Get("head")
 	default:
-		return d.document.Call("getElementById", id)
+		return d.byID(id)
+	}
+}
+
+// byID finds an element by id in the document, then inside the shadow roots
+// this root attached. A root whose host is out of the page is skipped, not
+// forgotten: a tab panel or a moved widget puts the host back, and a closed
+// root could not be found again. Roots are dropped with their owner (see
+// AttachShadow).
+func (d *domWasm) byID(id string) js.Value {
+	if v := d.document.Call("getElementById", id); !v.IsNull() || len(d.shadows) == 0 {
+		return v
+	}
+	for _, sr := range d.shadows {
+		if !sr.Get("host").Get("isConnected").Bool() {
+			continue
+		}
+		if v := sr.Call("getElementById", id); !v.IsNull() {
+			return v
+		}
 	}
+	return js.Null()
+}
+
+// activeElement returns the focused element, looking through shadow roots:
+// document.activeElement stops at the host of the root that holds the focus.
+func (d *domWasm) activeElement() js.Value {
+	focus := d.document.Get("activeElement")
+	for focus.Truthy() {
+		sr := focus.Get("shadowRoot")
+		if !sr.Truthy() {
+			// A closed root hides itself from its host; ours are known.
+			for _, own := range d.shadows {
+				if own.Get("host").Equal(focus) {
+					sr = own
+				}
+			}
+		}
+		if !sr.Truthy() || !sr.Get("activeElement").Truthy() {
+			break
+		}
+		focus = sr.Get("activeElement")
+	}
+	return focus
 }
 
 type domCtx struct {
@@ -160,6 +401,10 @@ func (c *domCtx) OnCleanup(fn func()) {
 
 // Render injects the component's content into the parent element.
 func (d *domWasm) Render(parentID string, component Component) error {
+	defer d.enter()()
+	if d.closed {
+		return fmt.Errf("dom: root is closed")
+	}
 	if d.document.IsNull() || d.document.IsUndefined() {
 		return fmt.Errf("document not found")
 	}
@@ -240,13 +485,19 @@ func (d *domWasm) initComponent(c Component) {
 	}
 
 	if initable, ok := c.(initable); ok {
+		// Init runs as the component, so what it registers on the package
+		// (head bindings, listeners on a Reference) is owned by it.
+		prevID := d.currentComponentID
+		d.currentComponentID = id
 		initable.Init(&domCtx{id: id, d: d})
+		d.currentComponentID = prevID
 	}
 	d.initedIDs = append(d.initedIDs, id)
 }
 
 // update re-renders the component and replaces it in the DOM.
 func (d *domWasm) update(id string) {
+	defer d.enter()()
 	if d.document.IsNull() || d.document.IsUndefined() {
 		d.Log("tinywasm/dom: document not found in update")
 		return
@@ -302,7 +553,7 @@ func (d *domWasm) update(id string) {
 	}
 
 	// Replace the element in the DOM
-	elRaw := d.document.Call("getElementById", id)
+	elRaw := d.byID(id)
 	if elRaw.IsNull() || elRaw.IsUndefined() {
 		if d.devMode {
 			d.Log("tinywasm/dom: component element not found during update:", id, "(this usually means the component root element has no ID)")
@@ -318,7 +569,7 @@ func (d *domWasm) update(id string) {
 	}
 
 	// Snapshot active element and cursor before outerHTML destroys them.
-	activeEl := d.document.Get("activeElement")
+	activeEl := d.activeElement()
 	activeID := ""
 	cursorStart, cursorEnd := 0, 0
 	if !activeEl.IsNull() && !activeEl.IsUndefined() {
@@ -334,6 +585,7 @@ func (d *domWasm) update(id string) {
 	}
 
 	elRaw.Set("outerHTML", html)
+	d.runDetach(id)
 
 	// Clear element from cache as it was replaced
 	d.removeFromElementCache(id)
@@ -359,9 +611,9 @@ func (d *domWasm) update(id string) {
 
 	// Restore focus and cursor to the element that was active before outerHTML replacement.
 	if activeID != "" {
-		restored := d.document.Call("getElementById", activeID)
+		restored := d.byID(activeID)
 		if !restored.IsNull() && !restored.IsUndefined() {
-			currentActive := d.document.Get("activeElement")
+			currentActive := d.activeElement()
 			alreadyActive := !currentActive.IsNull() && !currentActive.IsUndefined() &&
 				currentActive.Get("id").String() == activeID
 			if !alreadyActive {
@@ -388,6 +640,100 @@ func (d *domWasm) update(id string) {
 
 // Append injects the component's content after the last child of the parent element.
 func (d *domWasm) Append(parentID string, component Component) error {
+	if d.closed {
+		return fmt.Errf("dom: root is closed")
+	}
+	parent := d.getElement(parentID)
+	if parent.IsNull() || parent.IsUndefined() {
+		return fmt.Errf("parent element not found: %s", parentID)
+	}
+	d.insert(parentID, component, func(html string) {
+		parent.Call("insertAdjacentHTML", "beforeend", html)
+	})
+	return nil
+}
+
+// Prepend injects the component's content before the first child of the
+// parent element — the newest entry on top of a notification stack.
+func (d *domWasm) Prepend(parentID string, component Component) error {
+	if d.closed {
+		return fmt.Errf("dom: root is closed")
+	}
+	parent := d.getElement(parentID)
+	if parent.IsNull() || parent.IsUndefined() {
+		return fmt.Errf("parent element not found: %s", parentID)
+	}
+	d.insert(parentID, component, func(html string) {
+		parent.Call("insertAdjacentHTML", "afterbegin", html)
+	})
+	return nil
+}
+
+// InsertBefore injects the component's content right before the child of
+// parentID whose id is beforeID.
+func (d *domWasm) InsertBefore(parentID, beforeID string, component Component) error {
+	if d.closed {
+		return fmt.Errf("dom: root is closed")
+	}
+	parent := d.getElement(parentID)
+	if parent.IsNull() || parent.IsUndefined() {
+		return fmt.Errf("parent element not found: %s", parentID)
+	}
+	before := d.getElement(beforeID)
+	if !before.Truthy() || !before.Get("parentNode").Equal(parent) {
+		return fmt.Errf("element %s is not a child of %s", beforeID, parentID)
+	}
+	d.insert(parentID, component, func(html string) {
+		before.Call("insertAdjacentHTML", "beforebegin", html)
+	})
+	return nil
+}
+
+// Replace puts component in the place old occupies. Old is unmounted first —
+// its listeners, subscriptions and OnCleanup run while its node still marks
+// the spot — and the new component takes over old's slot among its parent's
+// children.
+func (d *domWasm) Replace(old, component Component) error {
+	if d.closed {
+		return fmt.Errf("dom: root is closed")
+	}
+	if old == nil || component == nil {
+		return fmt.Errf("Replace: nil component")
+	}
+	oldID := old.GetID()
+	oldEl := d.getElement(oldID)
+	if oldEl.IsNull() || oldEl.IsUndefined() {
+		return fmt.Errf("component element not found: %s", oldID)
+	}
+	parentID := ""
+	for _, item := range d.childrenMap {
+		for _, childID := range item.childIDs {
+			if childID == oldID {
+				parentID = item.parentID
+			}
+		}
+	}
+	// Not tracked (markup from the server, or a mount older than the child
+	// tracking): the nearest DOM ancestor with an id is the parent a later
+	// Render wipes, so the replacement is tracked there.
+	for p := oldEl.Get("parentElement"); parentID == "" && p.Truthy(); p = p.Get("parentElement") {
+		parentID = p.Get("id").String()
+	}
+	d.unmountRecursive(old)
+	d.dropChild(oldID)
+	d.insert(parentID, component, func(html string) {
+		oldEl.Call("insertAdjacentHTML", "beforebegin", html)
+		oldEl.Call("remove")
+		d.removeFromElementCache(oldID)
+	})
+	return nil
+}
+
+// insert runs the mount sequence shared by Append, Prepend, InsertBefore and
+// Replace: Init, render, place the markup, track the component as a child of
+// parentID (so a later Render over the parent unmounts it), wire, Mounted.
+func (d *domWasm) insert(parentID string, component Component, place func(html string)) {
+	defer d.enter()()
 	if component.GetID() == "" {
 		component.SetID(generateID())
 	}
@@ -396,33 +742,31 @@ func (d *domWasm) Append(parentID string, component Component) error {
 
 	var children []Component
 	var html string
-	var appendRoot *Element
+	var insertRoot *Element
 	if vr, ok := component.(ViewRenderer); ok {
 		root := vr.Render()
 		injectComponentID(root, component.GetID())
 		html = d.renderToHTML(root, &children, component.GetID())
-		appendRoot = root
+		insertRoot = root
 	} else if en, ok := component.(elementNode); ok {
-		appendRoot = en.AsElement()
-		html = d.renderToHTML(appendRoot, &children, component.GetID())
+		insertRoot = en.AsElement()
+		html = d.renderToHTML(insertRoot, &children, component.GetID())
 	} else if el, ok := component.(*Element); ok {
-		appendRoot = el
-		html = d.renderToHTML(appendRoot, &children, component.GetID())
+		insertRoot = el
+		html = d.renderToHTML(insertRoot, &children, component.GetID())
 	} else {
 		html = component.String()
 	}
-
-	parent := d.getElement(parentID)
-	if parent.IsNull() || parent.IsUndefined() {
-		return fmt.Errf("parent element not found: %s", parentID)
-	}
-	parent.Call("insertAdjacentHTML", "beforeend", html)
+	place(html)
 
 	d.trackComponent(component)
-	if appendRoot != nil {
-		d.storeRoot(component.GetID(), appendRoot)
+	if insertRoot != nil {
+		d.storeRoot(component.GetID(), insertRoot)
 	}
 	d.trackChildren(component.GetID(), children)
+	if parentID != "" {
+		d.addChild(parentID, component.GetID())
+	}
 
 	prevID := d.currentComponentID
 	d.currentComponentID = component.GetID()
@@ -437,17 +781,48 @@ func (d *domWasm) Append(parentID string, component Component) error {
 	if m, ok := component.(mountable); ok {
 		m.Mounted()
 	}
+}
 
-	return nil
+// addChild records id as one more child of parentID, next to the ones already
+// there (trackChildren replaces the list; Append and friends add to it).
+func (d *domWasm) addChild(parentID, id string) {
+	for i, item := range d.childrenMap {
+		if item.parentID == parentID {
+			d.childrenMap[i].childIDs = append(item.childIDs, id)
+			return
+		}
+	}
+	d.childrenMap = append(d.childrenMap, struct {
+		parentID string
+		childIDs []string
+	}{parentID, []string{id}})
 }
 
-// unmount removes a component from the DOM and recursively cleans up children.
-func (d *domWasm) unmount(component Component) {
+// dropChild forgets id as the child of whichever parent tracked it.
+func (d *domWasm) dropChild(id string) {
+	for i, item := range d.childrenMap {
+		for j, childID := range item.childIDs {
+			if childID == id {
+				d.childrenMap[i].childIDs = append(item.childIDs[:j], item.childIDs[j+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Unmount removes a component from the DOM and recursively cleans up
+// children: listeners, subscriptions and OnCleanup all run.
+func (d *domWasm) Unmount(component Component) {
+	defer d.enter()()
+	if component == nil {
+		return
+	}
 	d.unmountRecursive(component)
+	d.dropChild(component.GetID())
 
 	// Remove the element from the DOM
 	id := component.GetID()
-	el := d.document.Call("getElementById", id)
+	el := d.byID(id)
 	if !el.IsNull() && !el.IsUndefined() {
 		el.Call("remove")
 	}
@@ -466,8 +841,8 @@ func (d *domWasm) renderToHTML(el *Element, comps *[]Component, ownerID string)
 	beginPass()
 	defer endPass()
 
-	// If the element has events or bindings but no ID, generate one
-	if (len(el.events) > 0 || len(el.bindings) > 0 || el.autofocus) && el.id == "" {
+	// If the element has events, bindings or a ref but no ID, generate one
+	if el.needsID() && el.id == "" {
 		el.id = generateID()
 	}
 
@@ -476,8 +851,9 @@ func (d *domWasm) renderToHTML(el *Element, comps *[]Component, ownerID string)
 			id      string
 			ownerID string
 			name    string
+			opts    ListenerOptions
 			handler func(Event)
-		}{el.id, ownerID, ev.Name, ev.Handler})
+		}{el.id, ownerID, ev.Name, ev.Opts, ev.Handler})
 	}
 
 	s := "<" + el.tag
@@ -488,7 +864,9 @@ func (d *domWasm) renderToHTML(el *Element, comps *[]Component, ownerID string)
 
 	// Apply bindings initial state
 	classes := el.classes
-	attrs := el.attrs
+	attrs := append([]fmt.KeyValue(nil), el.attrs...) // bindings write into it; never into the builder
+	styles := append([]fmt.KeyValue(nil), el.styles...)
+	var selected []string // option values a select binding marks selected
 	textContent := ""
 	hasTextContent := false
 	var boundChildren []*Element
@@ -514,6 +892,10 @@ func (d *domWasm) renderToHTML(el *Element, comps *[]Component, ownerID string)
 				val = b.fnString()
 			}
 			// Replace existing attr if found
+			if b.optional && val == "" {
+				attrs = removeKeyValue(attrs, b.name)
+				break
+			}
 			found := false
 			for i, attr := range attrs {
 				if attr.Key == b.name {
@@ -573,8 +955,39 @@ func (d *domWasm) renderToHTML(el *Element, comps *[]Component, ownerID string)
 			if sig, ok := b.signal.(*SignalNodes); ok {
 				boundChildren = append(boundChildren, sig.Get()...)
 			}
+		case "with":
+			attrs = setKeyValue(attrs, "value", b.formatted())
+		case "editable":
+			attrs = setKeyValue(attrs, "contenteditable", "true")
+			textContent = b.editableContent()
+			hasTextContent = true
+		case "checked":
+			if b.boolValue() {
+				attrs = setKeyValue(attrs, "checked", "")
+			}
+		case "radio":
+			if value, ok := attrValue(el.attrs, "value"); ok && value == b.stringValue() {
+				attrs = setKeyValue(attrs, "checked", "")
+			}
+		case "select":
+			selected = []string{b.stringValue()}
+		case "selectmulti":
+			selected = fmt.Convert(b.stringValue()).Split()
+		case "style":
+			styles = setKeyValue(styles, b.name, b.stringValue())
+		case "classname":
+			classes = append(classes[:len(classes):len(classes)], fmt.Convert(b.stringValue()).Split()...)
+		case "prop":
+			if attr, ok := propAttrName(b.name); ok {
+				attrs = setKeyValue(attrs, attr, b.stringValue())
+			}
+		case "propbool":
+			if attr, ok := propAttrName(b.name); ok && b.boolValue() {
+				attrs = setKeyValue(attrs, attr, "")
+			}
 		}
 	}
+	attrs = mergeStyle(attrs, styles)
 
 	if len(classes) > 0 {
 		s += " class='"
@@ -603,6 +1016,9 @@ func (d *domWasm) renderToHTML(el *Element, comps *[]Component, ownerID string)
 		for _, child := range el.children {
 			switch v := child.(type) {
 			case *Element:
+				if selected != nil {
+					v = markSelected(v, selected)
+				}
 				s += d.renderToHTML(v, comps, ownerID)
 			case string:
 				s += v
@@ -714,12 +1130,14 @@ func (d *domWasm) unmountRecursive(c Component) {
 			d.cleanupListeners(childID)
 			d.cleanupSignalSubscriptions(childID)
 			d.runCleanups(childID)
+			d.runDetach(childID)
 		}
 	}
 
 	d.cleanupListeners(c.GetID())
 	d.cleanupSignalSubscriptions(c.GetID())
 	d.runCleanups(c.GetID())
+	d.runDetach(c.GetID())
 	d.untrackComponent(c.GetID())
 	d.clearRoot(c.GetID())
 
@@ -760,6 +1178,7 @@ func (d *domWasm) cleanupChildren(parentID string) {
 			d.unmountRecursive(childComp)
 		} else {
 			d.cleanupListeners(childID)
+			d.runDetach(childID)
 		}
 	}
 }
@@ -867,7 +1286,7 @@ func (d *domWasm) wirePendingEvents() {
 			// Track listener for the component that owns the element
 			prev := d.currentComponentID
 			d.currentComponentID = pe.ownerID
-			el.On(pe.name, pe.handler)
+			el.OnWith(pe.name, pe.opts, pe.handler)
 			d.currentComponentID = prev
 		}
 	}
@@ -887,25 +1306,13 @@ func (d *domWasm) cleanupListeners(id string) {
 
 	if compIndex != -1 {
 		for _, key := range keysToRemove {
-			// Find and remove from eventFuncs
 			for i := 0; i < len(d.eventFuncs); i++ {
-				ef := d.eventFuncs[i]
-				if ef.key == key {
-					// Split key into id::type
-					parts := d.splitEventKey(key)
-					if len(parts) == 2 && !ef.val.IsNull() && !ef.val.IsUndefined() {
-						eventType := parts[1]
-						ef.val.Call("removeEventListener", eventType, ef.fn)
-					}
-					ef.fn.Release()
-
-					// Remove from slice
-					lastIdx := len(d.eventFuncs) - 1
-					d.eventFuncs[i] = d.eventFuncs[lastIdx]
-					d.eventFuncs = d.eventFuncs[:lastIdx]
+				if d.eventFuncs[i].key == key {
+					d.dropListener(i)
 					i--
 				}
 			}
+			d.undelegate(key)
 		}
 		// Remove from componentListeners
 		lastIdx := len(d.componentListeners) - 1
@@ -914,10 +1321,40 @@ func (d *domWasm) cleanupListeners(id string) {
 	}
 }
 
+// unlisten removes the listener whose js.Func is fn, if it is still
+// registered.
+func (d *domWasm) unlisten(fn js.Func) {
+	for i, ef := range d.eventFuncs {
+		if ef.fn.Value.Equal(fn.Value) {
+			d.dropListener(i)
+			return
+		}
+	}
+}
+
+// dropListener removes eventFuncs[i] from its element, releases its js.Func
+// and deletes the entry, moving the last one into its place.
+func (d *domWasm) dropListener(i int) {
+	ef := d.eventFuncs[i]
+	if parts := d.splitEventKey(ef.key); len(parts) == 2 && !ef.val.IsNull() && !ef.val.IsUndefined() {
+		ef.val.Call("removeEventListener", parts[1], ef.fn, ef.capture)
+	}
+	// Aborting covers browsers where the capture flag alone would not match,
+	// and listeners a once already removed.
+	if ef.abort.Truthy() {
+		ef.abort.Call("abort")
+	}
+	ef.fn.Release()
+	last := len(d.eventFuncs) - 1
+	d.eventFuncs[i] = d.eventFuncs[last]
+	d.eventFuncs = d.eventFuncs[:last]
+}
+
 func (d *domWasm) wireBindings(id string) {
 	// Use the root stored during the first Render() call so IDs match the DOM.
 	// Calling Render() again would generate new auto-IDs that don't exist in the DOM,
 	// making all BindText/BindAttr/BindChildren subscriptions target phantom elements.
+	defer d.runMountHooks()
 	if root, ok := d.loadRoot(id); ok {
 		d.wireElementBindings(root, id)
 		return
@@ -969,10 +1406,32 @@ func (d *domWasm) wireElementBindings(el *Element, ownerID string) {
 	if el == nil {
 		return
 	}
+	if el.ref != nil {
+		if ref, ok := d.Get(el.id); ok {
+			r := el.ref
+			r.ref = ref
+			d.onDetach(ownerID, ref.(*elementWasm).val, func() {
+				if r.ref == ref { // not already refilled by a newer node
+					r.ref = nil
+				}
+			})
+		}
+	}
+	if len(el.mounts) > 0 {
+		if ref, ok := d.Get(el.id); ok {
+			for _, fn := range el.mounts {
+				d.pendingMounts = append(d.pendingMounts, struct {
+					owner string
+					ref   Reference
+					fn    func(Reference) func()
+				}{ownerID, ref, fn})
+			}
+		}
+	}
 	if el.autofocus {
 		if ref, ok := d.Get(el.id); ok {
 			// Focus iff nothing else is focused
-			activeEl := d.document.Get("activeElement")
+			activeEl := d.activeElement()
 			if activeEl.IsNull() || activeEl.IsUndefined() || activeEl.Get("tagName").String() == "BODY" {
 				ref.Focus()
 			}
@@ -1013,7 +1472,11 @@ func (d *domWasm) wireElementBindings(el *Element, ownerID string) {
 				} else if b.fnString != nil {
 					val = b.fnString()
 				}
-				ref.SetAttr(b.name, val)
+				if b.optional && val == "" {
+					ref.RemoveAttr(b.name)
+				} else {
+					ref.SetAttr(b.name, val)
+				}
 				if d.devMode {
 					d.Log("[dom] patch #"+el.id+" attr "+b.name+":", val)
 				}
@@ -1099,26 +1562,291 @@ func (d *domWasm) wireElementBindings(el *Element, ownerID string) {
 			tagName := ref.(*elementWasm).val.Get("tagName").String()
 			if tagName != "INPUT" && tagName != "TEXTAREA" {
 				if d.devMode {
-					d.Log("tinywasm/dom: Bind used on non-input element:", tagName)
+					d.Log("tinywasm/dom: Bind used on non-input element:", tagName, "(contenteditable: use BindEditable)")
 				}
 			}
 
+			// composing is true between compositionstart and compositionend:
+			// the field holds an unfinished IME sequence that must be neither
+			// committed nor overwritten. Only tracked when an option asks.
+			composing := false
+			opts := b.opts
 			updater = func() {
 				val := sig.Get()
-				// Skip if activeElement to avoid cursor jumps
-				activeEl := d.document.Get("activeElement")
-				if !activeEl.IsNull() && !activeEl.IsUndefined() && activeEl.Get("id").String() == el.id {
+				if ref.Value() == val {
 					return
 				}
-				if ref.Value() != val {
+				// A focused field is skipped to avoid cursor jumps, unless the
+				// binding forces the write — then the selection is put back.
+				activeEl := d.activeElement()
+				if !activeEl.IsNull() && !activeEl.IsUndefined() && activeEl.Get("id").String() == el.id {
+					if opts&BindForceWrite == 0 || composing {
+						return
+					}
+					input := ref.(*elementWasm).val
+					start, end := input.Get("selectionStart"), input.Get("selectionEnd")
 					ref.SetValue(val)
+					// email/number inputs have no selection (null), and
+					// setSelectionRange throws on them.
+					if !start.IsNull() && !start.IsUndefined() {
+						input.Call("setSelectionRange", start, end)
+					}
+					return
 				}
+				ref.SetValue(val)
 			}
 
-			// Listen for input changes
-			ref.On("input", func(e Event) {
+			commitOn := "input"
+			if opts&BindOnChange != 0 {
+				commitOn = "change"
+			}
+			ref.On(commitOn, func(e Event) {
+				// Only BindDeferComposition holds commits back; it is also the
+				// one that commits at compositionend. ForceWrite tracks the
+				// composition for its writes alone, so the last input of the
+				// sequence — which fires before compositionend — still lands.
+				if composing && opts&BindDeferComposition != 0 {
+					return
+				}
 				sig.Set(ref.Value())
 			})
+			if opts&(BindDeferComposition|BindForceWrite) != 0 {
+				ref.On("compositionstart", func(e Event) {
+					composing = true
+				})
+				ref.On("compositionend", func(e Event) {
+					composing = false
+					// The input events fired during composition were skipped;
+					// commit the finished text now unless commits wait for change.
+					if opts&BindDeferComposition != 0 && opts&BindOnChange == 0 {
+						sig.Set(ref.Value())
+					}
+				})
+			}
+		case "style":
+			updater = func() {
+				val := b.stringValue()
+				style := ref.(*elementWasm).val.Get("style")
+				if val == "" {
+					style.Call("removeProperty", b.name)
+				} else {
+					style.Call("setProperty", b.name, val)
+				}
+				if d.devMode {
+					d.Log("[dom] patch #"+el.id+" style "+b.name+":", val)
+				}
+			}
+		case "classname":
+			// applied is what the previous value added: only those may be
+			// removed, so a class also set statically with Class survives.
+			applied := fmt.Convert(b.stringValue()).Split()
+			updater = func() {
+				next := fmt.Convert(b.stringValue()).Split()
+				classList := ref.(*elementWasm).val.Get("classList")
+				for _, c := range applied {
+					if !containsString(next, c) && !containsString(el.classes, c) {
+						classList.Call("remove", c)
+					}
+				}
+				for _, c := range next {
+					classList.Call("add", c)
+				}
+				applied = next
+				if d.devMode {
+					d.Log("[dom] patch #"+el.id+" classname:", next)
+				}
+			}
+		case "prop":
+			// Properties with no attribute form never reached the markup, so
+			// the first value has to be written here, not left to the HTML.
+			updater = func() {
+				val := b.stringValue()
+				ref.(*elementWasm).val.Set(b.name, val)
+				if d.devMode {
+					d.Log("[dom] patch #"+el.id+" prop "+b.name+":", val)
+				}
+			}
+			if b.signal != nil {
+				updater()
+			}
+		case "propbool":
+			updater = func() {
+				on := b.boolValue()
+				ref.(*elementWasm).val.Set(b.name, on)
+				if d.devMode {
+					d.Log("[dom] patch #"+el.id+" prop "+b.name+":", on)
+				}
+			}
+			if b.signal != nil {
+				updater()
+			}
+		case "with":
+			sig, ok := b.signal.(*SignalString)
+			if !ok {
+				continue
+			}
+			updater = func() {
+				// Never reformat under the user's fingers: "1,2" mid-typing
+				// must not become "12.00". change below catches up.
+				activeEl := d.activeElement()
+				if !activeEl.IsNull() && !activeEl.IsUndefined() && activeEl.Get("id").String() == el.id {
+					return
+				}
+				if display := b.formatted(); ref.Value() != display {
+					ref.SetValue(display)
+				}
+			}
+			commit := func() bool {
+				raw := ref.Value()
+				val := raw
+				if b.parse != nil {
+					parsed, err := b.parse(raw)
+					if err != nil {
+						b.errs.Set(err.Error())
+						return false
+					}
+					val = parsed
+				}
+				b.errs.Set("")
+				sig.Set(val)
+				return true
+			}
+			ref.On("input", func(e Event) {
+				commit()
+			})
+			ref.On("change", func(e Event) {
+				if commit() {
+					ref.SetValue(b.formatted())
+				}
+			})
+		case "editable":
+			sig, ok := b.signal.(*SignalString)
+			if !ok {
+				continue
+			}
+			node := ref.(*elementWasm).val
+			read := func() string {
+				if b.name == "html" {
+					return b.format(node.Get("innerHTML").String())
+				}
+				return node.Get("innerText").String()
+			}
+			// An update that lands mid-composition is held in pending and
+			// applied once the composition ends, instead of being lost.
+			composing, pending := false, false
+			updater = func() {
+				if composing {
+					pending = true
+					return
+				}
+				next := sig.Get()
+				if b.name == "html" {
+					next = b.format(next)
+				}
+				if read() == next {
+					return
+				}
+				start, end, selected := selectionOffsets(node)
+				if b.name == "html" {
+					node.Set("innerHTML", next)
+				} else {
+					node.Set("innerText", next)
+				}
+				if selected {
+					setSelectionOffsets(node, start, end)
+				}
+				if d.devMode {
+					d.Log("[dom] patch #"+el.id+" editable:", next)
+				}
+			}
+			ref.On("input", func(e Event) {
+				if !composing {
+					sig.Set(read())
+				}
+			})
+			ref.On("compositionstart", func(e Event) {
+				composing = true
+			})
+			ref.On("compositionend", func(e Event) {
+				composing = false
+				if pending {
+					pending = false
+					updater()
+					return
+				}
+				sig.Set(read())
+			})
+		case "checked":
+			sig, ok := b.signal.(*SignalBool)
+			if !ok {
+				continue
+			}
+			updater = func() {
+				on := sig.Get()
+				ref.(*elementWasm).val.Set("checked", on)
+				if d.devMode {
+					d.Log("[dom] patch #"+el.id+" checked:", on)
+				}
+			}
+			ref.On("change", func(e Event) {
+				sig.Set(ref.Checked())
+			})
+		case "radio":
+			sig, ok := b.signal.(*SignalString)
+			if !ok {
+				continue
+			}
+			updater = func() {
+				on := sig.Get() == ref.Value()
+				ref.(*elementWasm).val.Set("checked", on)
+				if d.devMode {
+					d.Log("[dom] patch #"+el.id+" radio:", on)
+				}
+			}
+			// Only the radio being checked fires "change"; the one losing the
+			// check learns about it through the signal, like everyone else.
+			ref.On("change", func(e Event) {
+				if ref.Checked() {
+					sig.Set(ref.Value())
+				}
+			})
+		case "select", "selectmulti":
+			sig, ok := b.signal.(*SignalString)
+			if !ok {
+				continue
+			}
+			multi := b.kind == "selectmulti"
+			updater = func() {
+				val := sig.Get()
+				if multi {
+					values := fmt.Convert(val).Split()
+					options := ref.(*elementWasm).val.Get("options")
+					for i, n := 0, options.Get("length").Int(); i < n; i++ {
+						opt := options.Call("item", i)
+						opt.Set("selected", containsString(values, opt.Get("value").String()))
+					}
+				} else if ref.Value() != val {
+					ref.SetValue(val)
+				}
+				if d.devMode {
+					d.Log("[dom] patch #"+el.id+" "+b.kind+":", val)
+				}
+			}
+			// The markup only marks options that are static children; options
+			// rendered from a BindChildren list are not, so start from the signal.
+			updater()
+			ref.On("change", func(e Event) {
+				if !multi {
+					sig.Set(ref.Value())
+					return
+				}
+				var values []string
+				selectedOpts := ref.(*elementWasm).val.Get("selectedOptions")
+				for i, n := 0, selectedOpts.Get("length").Int(); i < n; i++ {
+					values = append(values, selectedOpts.Call("item", i).Get("value").String())
+				}
+				sig.Set(fmt.JoinSlice(values, " "))
+			})
 		case "children":
 			sig, ok := b.signal.(*SignalNodes)
 			if !ok {
@@ -1133,6 +1861,18 @@ func (d *domWasm) wireElementBindings(el *Element, ownerID string) {
 			for _, n := range sig.Get() {
 				d.wireElementBindings(n, el.id)
 			}
+			// Rows are wired for the list element, not the component: when
+			// the component's nodes go, everything the rows registered —
+			// listeners, subscriptions, cleanups, detach hooks — goes with them.
+			if el.id != ownerID {
+				holder := el.id
+				d.onDetach(ownerID, ref.(*elementWasm).val, func() {
+					d.cleanupListeners(holder)
+					d.cleanupSignalSubscriptions(holder)
+					d.runCleanups(holder)
+					d.runDetach(holder)
+				})
+			}
 			updater = func() {
 				d.reconcileChildren(el.id, sig.Get())
 			}
@@ -1181,6 +1921,60 @@ func (d *domWasm) cleanupSignalSubscriptions(id string) {
 	}
 }
 
+// runMountHooks calls the OnMount hooks collected while wiring, now that the
+// elements' handlers and bindings are all in place, and keeps their cleanups
+// with the element's owner.
+func (d *domWasm) runMountHooks() {
+	pending := d.pendingMounts
+	d.pendingMounts = nil
+	for _, m := range pending {
+		if cleanup := m.fn(m.ref); cleanup != nil {
+			d.onDetach(m.owner, m.ref.(*elementWasm).val, cleanup)
+		}
+	}
+}
+
+// onDetach registers fn to run when node, wired for owner, leaves the page.
+func (d *domWasm) onDetach(owner string, node js.Value, fn func()) {
+	d.detach = append(d.detach, struct {
+		owner string
+		val   js.Value
+		fn    func()
+	}{owner, node, fn})
+}
+
+// runDetach runs the teardown registered for owner: its nodes are gone, or
+// about to be replaced, whenever the owner unmounts or re-renders. Only the
+// owner's entries are visited; nothing asks the DOM.
+func (d *domWasm) runDetach(owner string) {
+	d.runDetachIf(func(item js.Value, o string) bool { return o == owner })
+}
+
+// runDetachWithin runs the teardown of owner's nodes inside removed, the row a
+// keyed reconcile just dropped; the owner's other rows stay.
+func (d *domWasm) runDetachWithin(owner string, removed js.Value) {
+	d.runDetachIf(func(item js.Value, o string) bool {
+		return o == owner && removed.Call("contains", item).Bool()
+	})
+}
+
+func (d *domWasm) runDetachIf(match func(node js.Value, owner string) bool) {
+	var run []func()
+	kept := d.detach[:0]
+	for _, item := range d.detach {
+		if match(item.val, item.owner) {
+			run = append(run, item.fn)
+		} else {
+			kept = append(kept, item)
+		}
+	}
+	d.detach = kept
+	// Run after the list is settled: a teardown may register new entries.
+	for _, fn := range run {
+		fn()
+	}
+}
+
 func (d *domWasm) runCleanups(id string) {
 	for i := 0; i < len(d.cleanups); i++ {
 		if d.cleanups[i].id == id {
@@ -1192,6 +1986,7 @@ func (d *domWasm) runCleanups(id string) {
 }
 
 func (d *domWasm) reconcileChildren(parentID string, newNodes []*Element) {
+	defer d.enter()()
 	parent, ok := d.Get(parentID)
 	if !ok {
 		return
@@ -1285,17 +2080,72 @@ func (d *domWasm) reconcileChildren(parentID string, newNodes []*Element) {
 		last := parentVal.Get("lastElementChild")
 		lastID := last.Get("id").String()
 		last.Call("remove")
+		d.undelegateTree(last)
 		d.cleanupListeners(lastID)
 		d.cleanupSignalSubscriptions(lastID)
 		d.runCleanups(lastID)
+		d.runDetachWithin(parentID, last)
 	}
 
 	d.wirePendingEvents()
+	d.runMountHooks()
 	for _, c := range comps {
 		d.mountRecursive(c)
 	}
 }
 
+// selectionOffsets returns the selection inside node as text offsets, start
+// and end (equal for a caret); ok is false when the selection is elsewhere.
+// Offsets rather than (node, offset) pairs because a programmatic update
+// replaces the text nodes the pairs point into.
+func selectionOffsets(node js.Value) (start, end int, ok bool) {
+	sel := js.Global().Call("getSelection")
+	if sel.IsNull() || sel.Get("rangeCount").Int() == 0 {
+		return 0, 0, false
+	}
+	r := sel.Call("getRangeAt", 0)
+	if !node.Call("contains", r.Get("startContainer")).Bool() || !node.Call("contains", r.Get("endContainer")).Bool() {
+		return 0, 0, false
+	}
+	return textOffset(node, r.Get("startContainer"), r.Get("startOffset")),
+		textOffset(node, r.Get("endContainer"), r.Get("endOffset")), true
+}
+
+// textOffset is the length of node's text before the boundary point
+// (container, offset).
+func textOffset(node, container, offset js.Value) int {
+	pre := js.Global().Get("document").Call("createRange")
+	pre.Call("selectNodeContents", node)
+	pre.Call("setEnd", container, offset)
+	return pre.Call("toString").Get("length").Int()
+}
+
+// setSelectionOffsets selects from start to end, text offsets inside node,
+// walking its text nodes; an offset past the end lands at the end.
+func setSelectionOffsets(node js.Value, start, end int) {
+	r := js.Global().Get("document").Call("createRange")
+	sc, so := textPoint(node, start)
+	ec, eo := textPoint(node, end)
+	r.Call("setStart", sc, so)
+	r.Call("setEnd", ec, eo)
+	sel := js.Global().Call("getSelection")
+	sel.Call("removeAllRanges")
+	sel.Call("addRange", r)
+}
+
+// textPoint is the boundary point offset characters into node's text.
+func textPoint(node js.Value, offset int) (js.Value, int) {
+	walker := js.Global().Get("document").Call("createTreeWalker", node, 4) // NodeFilter.SHOW_TEXT
+	for n := walker.Call("nextNode"); !n.IsNull(); n = walker.Call("nextNode") {
+		length := n.Get("length").Int()
+		if offset <= length {
+			return n, offset
+		}
+		offset -= length
+	}
+	return node, node.Get("childNodes").Get("length").Int()
+}
+
 // Show keeps content mounted and toggles its visibility with cond.
 // The subtree is built and attached ONCE — a builder re-run that re-attaches
 // captured elements (the v0.12 panic) is unrepresentable: there is no builder.
@@ -1306,12 +2156,13 @@ func Show(cond *SignalBool, content Component) *Element {
 	containerID := generateID()
 	container := NewElement("div").ID(containerID)
 	if !cond.Get() {
-		container.Attr("style", "display:none")
+		container.Style("display", "none")
 	}
 	container.Child(content)
 
+	d := current()
 	updater := func() {
-		if ref, ok := instance.Get(containerID); ok {
+		if ref, ok := d.Get(containerID); ok {
 			display := ""
 			if !cond.Get() {
 				display = "none"
@@ -1322,7 +2173,7 @@ func Show(cond *SignalBool, content Component) *Element {
 	unsub := cond.subscribe(updater)
 
 	// Register unsub to be called when container is unmounted
-	instance.(*domWasm).unsubs = append(instance.(*domWasm).unsubs, struct {
+	d.unsubs = append(d.unsubs, struct {
 		id    string
 		unsub func()
 	}{containerID, unsub})
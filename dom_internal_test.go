@@ -78,10 +78,12 @@ func TestInternalWasm(t *testing.T) {
 
 		d.eventFuncs = append(d.eventFuncs,
 			struct {
-				key string
-				val js.Value
-				fn  js.Func
-			}{"id1::click", js.Null(), js.Func{}},
+				key     string
+				val     js.Value
+				fn      js.Func
+				capture bool
+				abort   js.Value
+			}{"id1::click", js.Null(), js.Func{}, false, js.Undefined()},
 		)
 
 		d.cleanupListeners("id1")
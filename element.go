@@ -11,11 +11,14 @@ type Element struct {
 	key       string
 	classes   []string
 	attrs     []fmt.KeyValue
+	styles    []fmt.KeyValue
 	events    []eventHandler
 	bindings  []binding
 	children  []any
 	void      bool
 	autofocus bool
+	ref       *Ref
+	mounts    []func(Reference) func()
 
 	// attached reports that this element is already somebody's child. An
 	// element has exactly one parent: ids are minted per element, so the same
@@ -27,12 +30,68 @@ type Element struct {
 }
 
 type binding struct {
-	kind     string // "text", "attr", "class", "attrbool", "state", "value", "children"
-	name     string // attr name or class name
+	kind     string     // "text", "attr", "class", "attrbool", "state", "value", "children", "style", "prop", "propbool", "classname", "checked", "radio", "select", "selectmulti", "with", "editable"
+	name     string     // attr name, class name, style property, JS property; "text"/"html" for editable
+	optional bool       // "attr": an empty value removes the attribute
+	opts     BindOption // "value": commit and write modes
 	state    StateAttr
 	signal   subscribable
 	fnString func() string
 	fnBool   func() bool
+
+	// "with": the converter pair and where parse errors go.
+	// "editable": format is the HTML sanitizer.
+	format func(string) string
+	parse  func(string) (string, error)
+	errs   *SignalString
+}
+
+// stringValue reads a string binding: its signal, or its computed func.
+func (b binding) stringValue() string {
+	if b.signal != nil {
+		if sig, ok := b.signal.(*SignalString); ok {
+			return sig.Get()
+		}
+		return ""
+	}
+	if b.fnString != nil {
+		return b.fnString()
+	}
+	return ""
+}
+
+// boolValue reads a boolean binding: its signal, or its computed func.
+func (b binding) boolValue() bool {
+	if b.signal != nil {
+		if sig, ok := b.signal.(*SignalBool); ok {
+			return sig.Get()
+		}
+		return false
+	}
+	if b.fnBool != nil {
+		return b.fnBool()
+	}
+	return false
+}
+
+// propAttrName maps a JS property to the content attribute SSR writes for it.
+// Only properties whose attribute carries the initial state are listed; the
+// rest (scrollTop, volume, indeterminate, ...) have no markup form.
+func propAttrName(prop string) (string, bool) {
+	switch prop {
+	case "value", "title", "placeholder", "lang", "dir", "src", "href", "alt",
+		"checked", "disabled", "selected", "multiple", "open", "hidden",
+		"muted", "loop", "autoplay", "controls", "required":
+		return prop, true
+	case "readOnly":
+		return "readonly", true
+	case "htmlFor":
+		return "for", true
+	case "tabIndex":
+		return "tabindex", true
+	default:
+		return "", false
+	}
 }
 
 // StateAttr is anything that names a data-state attribute and the value the
@@ -83,6 +142,42 @@ func (b *Element) Autofocus() *Element {
 	return b
 }
 
+// Ref asks the engine to fill r with this element's Reference once it is in
+// the page and wired, and to clear it when the element is removed with its
+// component, by the component's re-render, or with its BindChildren row.
+// Mounted and event handlers reach the node through r.Get — no manual ID, so
+// the component can be rendered twice without two nodes claiming one id.
+func (b *Element) Ref(r *Ref) *Element {
+	b.ref = r
+	return b
+}
+
+// OnMount runs fn with the element's Reference once it is in the page with its
+// handlers and bindings wired — on first render, on every update that
+// re-creates it, and for rows BindChildren inserts. The function fn returns,
+// if not nil, runs when the element leaves the page: with its component's
+// unmount or re-render, or with its BindChildren row. It is the place for a
+// third-party widget (chart, map, code editor) that needs a live node, without
+// promoting the element to a component:
+//
+//	html.Div().OnMount(func(ref dom.Reference) func() {
+//		chart := newChart(ref)
+//		return chart.Destroy
+//	})
+//
+// Never called on the backend.
+func (b *Element) OnMount(fn func(Reference) (cleanup func())) *Element {
+	b.mounts = append(b.mounts, fn)
+	return b
+}
+
+// needsID reports whether the engine has to find this node after insertion:
+// it has handlers, bindings, autofocus, a ref or mount hooks, so it gets an
+// id minted.
+func (b *Element) needsID() bool {
+	return len(b.events) > 0 || len(b.bindings) > 0 || b.autofocus || b.ref != nil || len(b.mounts) > 0
+}
+
 // Class adds a class to the element.
 func (b *Element) Class(class ...string) *Element {
 	b.classes = append(b.classes, class...)
@@ -101,12 +196,26 @@ func (b *Element) Attr(key, val string) *Element {
 	return b
 }
 
+// Style sets one inline style property. All Style calls, style bindings and a
+// raw Attr("style", ...) end up in a single style attribute, so setting one
+// property never drops another — Show's display:none included.
+func (b *Element) Style(prop, value string) *Element {
+	b.styles = setKeyValue(b.styles, prop, value)
+	return b
+}
+
 // On adds a generic event handler.
 func (b *Element) On(t string, h func(Event)) *Element {
 	b.events = append(b.events, eventHandler{Name: t, Handler: h})
 	return b
 }
 
+// OnWith adds an event handler with listener options (once, passive, capture).
+func (b *Element) OnWith(t string, opts ListenerOptions, h func(Event)) *Element {
+	b.events = append(b.events, eventHandler{Name: t, Opts: opts, Handler: h})
+	return b
+}
+
 // Child adds one or more elements or components as children.
 func (b *Element) Child(c ...Component) *Element {
 	for _, child := range c {
@@ -159,12 +268,43 @@ func (b *Element) BindAttr(name string, s *SignalString) *Element {
 	return b
 }
 
+// BindAttrOpt links an attribute to a SignalString, removing the attribute
+// while the value is "" instead of writing attr="" — the same rule
+// SetDocumentAttr follows. Use it where an empty attribute means something:
+// src="" refetches the page itself, href="" links to it, and an empty
+// aria-describedby points at nothing.
+func (b *Element) BindAttrOpt(name string, s *SignalString) *Element {
+	b.bindings = append(b.bindings, binding{kind: "attr", name: name, signal: s, optional: true})
+	return b
+}
+
+// BindAttrOptFunc is BindAttrOpt for a computed string.
+func (b *Element) BindAttrOptFunc(name string, fn func() string) *Element {
+	b.bindings = append(b.bindings, binding{kind: "attr", name: name, fnString: fn, optional: true})
+	return b
+}
+
 // BindClass toggles a class based on a SignalBool.
 func (b *Element) BindClass(class string, on *SignalBool) *Element {
 	b.bindings = append(b.bindings, binding{kind: "class", name: class, signal: on})
 	return b
 }
 
+// BindClassName links a whole space-separated set of classes to a SignalString
+// — a variant ("size-sm", "size-lg") or a theme picked by a string, without one
+// SignalBool per possible value. Each change removes the classes the previous
+// value added and adds the new ones; classes set with Class are never touched.
+func (b *Element) BindClassName(s *SignalString) *Element {
+	b.bindings = append(b.bindings, binding{kind: "classname", signal: s})
+	return b
+}
+
+// BindClassNameFunc links a set of classes to a computed string.
+func (b *Element) BindClassNameFunc(fn func() string) *Element {
+	b.bindings = append(b.bindings, binding{kind: "classname", fnString: fn})
+	return b
+}
+
 // BindAttrBool toggles a boolean attribute (disabled, checked, etc.) based on a SignalBool.
 func (b *Element) BindAttrBool(name string, on *SignalBool) *Element {
 	b.bindings = append(b.bindings, binding{kind: "attrbool", name: name, signal: on})
@@ -173,7 +313,172 @@ func (b *Element) BindAttrBool(name string, on *SignalBool) *Element {
 
 // Bind provides two-way binding for <input> and <textarea>.
 func (b *Element) Bind(s *SignalString) *Element {
-	b.bindings = append(b.bindings, binding{kind: "value", signal: s})
+	return b.BindValue(s, 0)
+}
+
+// BindOption selects how BindValue commits to the signal and writes to the
+// field. Options combine with |.
+type BindOption uint8
+
+const (
+	// BindOnChange commits on "change" (blur or Enter) instead of every
+	// "input" — a search that should not run per keystroke.
+	BindOnChange BindOption = 1 << iota
+	// BindDeferComposition skips the input events of an IME composition
+	// (accents, CJK) and commits the finished text at compositionend, so the
+	// signal never sees half-composed characters.
+	BindDeferComposition
+	// BindForceWrite writes signal changes into the field even while it has
+	// focus, keeping the caret/selection where it was — clearing a search box
+	// after submit. Writes are still held back during a composition; what the
+	// user types is committed as usual.
+	BindForceWrite
+)
+
+// BindValue is Bind with options. Bind(s) is BindValue(s, 0): commit on every
+// input, never write into the focused field.
+func (b *Element) BindValue(s *SignalString, opts BindOption) *Element {
+	b.bindings = append(b.bindings, binding{kind: "value", signal: s, opts: opts})
+	return b
+}
+
+// BindStyle links one inline style property to a SignalString. An empty value
+// removes the property.
+func (b *Element) BindStyle(prop string, s *SignalString) *Element {
+	b.bindings = append(b.bindings, binding{kind: "style", name: prop, signal: s})
+	return b
+}
+
+// BindStyleFunc links one inline style property to a computed string.
+func (b *Element) BindStyleFunc(prop string, fn func() string) *Element {
+	b.bindings = append(b.bindings, binding{kind: "style", name: prop, fnString: fn})
+	return b
+}
+
+// BindCSSVar links a CSS custom property on this element to a SignalString.
+// name may be given with or without the leading "--". Everything below the
+// element that reads var(--name) follows the signal, which is how a theme
+// token is overridden for one subtree.
+func (b *Element) BindCSSVar(name string, s *SignalString) *Element {
+	return b.BindStyle(cssVarName(name), s)
+}
+
+// BindCSSVarFunc links a CSS custom property to a computed string.
+func (b *Element) BindCSSVarFunc(name string, fn func() string) *Element {
+	return b.BindStyleFunc(cssVarName(name), fn)
+}
+
+// BindProp links a live JS property (element[name] = value) to a SignalString,
+// for state that has no attribute or whose attribute stops tracking it: value on
+// a <select>, scrollTop, volume, currentTime. Where the property reflects an
+// attribute (value, title, ...) SSR writes that attribute; otherwise the
+// property exists only in the browser and SSR writes nothing.
+func (b *Element) BindProp(name string, s *SignalString) *Element {
+	b.bindings = append(b.bindings, binding{kind: "prop", name: name, signal: s})
+	return b
+}
+
+// BindPropFunc links a live JS property to a computed string.
+func (b *Element) BindPropFunc(name string, fn func() string) *Element {
+	b.bindings = append(b.bindings, binding{kind: "prop", name: name, fnString: fn})
+	return b
+}
+
+// BindPropBool links a boolean JS property (indeterminate, open, muted, ...) to
+// a SignalBool. SSR writes the boolean attribute while true when the property
+// has one; indeterminate, for one, has none.
+func (b *Element) BindPropBool(name string, on *SignalBool) *Element {
+	b.bindings = append(b.bindings, binding{kind: "propbool", name: name, signal: on})
+	return b
+}
+
+// BindPropBoolFunc links a boolean JS property to a computed boolean.
+func (b *Element) BindPropBoolFunc(name string, fn func() bool) *Element {
+	b.bindings = append(b.bindings, binding{kind: "propbool", name: name, fnBool: fn})
+	return b
+}
+
+// BindWith provides two-way binding through a converter, for numbers, dates
+// and currency kept in a SignalString in canonical form ("1234.5") but shown
+// formatted ("1,234.50"). The field displays format(s); what the user types is
+// passed to parse and, when it parses, written to s. A parse error is written
+// to errs (nil to ignore) and cleared by the next successful parse, so the
+// field can show it with BindText. On "change" (blur, Enter) the field is
+// rewritten with the formatted value. A nil format or parse passes through.
+func (b *Element) BindWith(s *SignalString, format func(string) string, parse func(string) (string, error), errs *SignalString) *Element {
+	b.bindings = append(b.bindings, binding{kind: "with", signal: s, format: format, parse: parse, errs: errs})
+	return b
+}
+
+// formatted is the display form of a "with" binding's value.
+func (b binding) formatted() string {
+	if b.format == nil {
+		return b.stringValue()
+	}
+	return b.format(b.stringValue())
+}
+
+// BindEditable provides two-way binding for a contenteditable element as plain
+// text: the element is made editable, its text follows s and what the user
+// types is written back. Line breaks round-trip through innerText.
+//
+// Programmatic updates keep the selection where it was (as text offsets), and
+// nothing is written in either direction while an IME composition is open. At
+// compositionend the finished text is committed — unless s changed during the
+// composition, in which case that newer value wins and replaces the element.
+func (b *Element) BindEditable(s *SignalString) *Element {
+	b.bindings = append(b.bindings, binding{kind: "editable", name: "text", signal: s})
+	return b
+}
+
+// BindEditableHTML is BindEditable for rich text: s holds HTML. Everything that
+// crosses into or out of the element goes through sanitize first — the markup
+// a user can paste into a contenteditable is arbitrary, and s is likely to be
+// rendered elsewhere. A nil sanitize panics.
+func (b *Element) BindEditableHTML(s *SignalString, sanitize func(string) string) *Element {
+	if sanitize == nil {
+		panic(fmt.Err("dom: BindEditableHTML", "sanitize", "nil"))
+	}
+	b.bindings = append(b.bindings, binding{kind: "editable", name: "html", signal: s, format: sanitize})
+	return b
+}
+
+// editableContent is the markup an editable binding serializes.
+func (b binding) editableContent() string {
+	if b.name == "html" {
+		return b.format(b.stringValue())
+	}
+	return fmt.Convert(b.stringValue()).EscapeHTML()
+}
+
+// BindChecked provides two-way binding for a checkbox: the live .checked
+// property follows s, and the "change" event writes the user's toggle back.
+func (b *Element) BindChecked(s *SignalBool) *Element {
+	b.bindings = append(b.bindings, binding{kind: "checked", signal: s})
+	return b
+}
+
+// BindRadio binds one radio input of a group to a SignalString holding the
+// group's selected value. Give every radio of the group the same signal and its
+// own Attr("value", ...): the one whose value equals the signal is checked, and
+// checking one writes its value to the signal.
+func (b *Element) BindRadio(group *SignalString) *Element {
+	b.bindings = append(b.bindings, binding{kind: "radio", signal: group})
+	return b
+}
+
+// BindSelect provides two-way binding for a single <select>: the option whose
+// value equals s is selected, and "change" writes the user's choice back.
+func (b *Element) BindSelect(s *SignalString) *Element {
+	b.bindings = append(b.bindings, binding{kind: "select", signal: s})
+	return b
+}
+
+// BindSelectMulti provides two-way binding for a <select multiple>. s holds the
+// selected option values separated by single spaces — the same set encoding
+// as BindClassName — so option values must not contain spaces.
+func (b *Element) BindSelectMulti(s *SignalString) *Element {
+	b.bindings = append(b.bindings, binding{kind: "selectmulti", signal: s})
 	return b
 }
 
@@ -269,6 +574,12 @@ func (b *Element) Children() []Component {
 
 // Helper to convert Element to HTML string (recursive)
 func elementToHTML(el *Element) string {
+	return elementToHTMLWith(el, Component.String)
+}
+
+// elementToHTMLWith is elementToHTML with nested components serialized by
+// childHTML: on the server, the Document render that initializes them first.
+func elementToHTMLWith(el *Element, childHTML func(Component) string) string {
 	if el == nil {
 		return ""
 	}
@@ -282,7 +593,9 @@ func elementToHTML(el *Element) string {
 	}
 
 	classes := el.classes
-	attrs := el.attrs
+	attrs := append([]fmt.KeyValue(nil), el.attrs...) // bindings write into it; never into the builder
+	styles := append([]fmt.KeyValue(nil), el.styles...)
+	var selected []string // option values a select binding marks selected
 	textContent := ""
 	hasTextContent := false
 
@@ -306,6 +619,10 @@ func elementToHTML(el *Element) string {
 			} else if b.fnString != nil {
 				val = b.fnString()
 			}
+			if b.optional && val == "" {
+				attrs = removeKeyValue(attrs, b.name)
+				break
+			}
 			found := false
 			for i, attr := range attrs {
 				if attr.Key == b.name {
@@ -361,8 +678,39 @@ func elementToHTML(el *Element) string {
 				}
 			}
 			attrs = append(attrs, fmt.KeyValue{Key: "value", Value: val})
+		case "with":
+			attrs = setKeyValue(attrs, "value", b.formatted())
+		case "editable":
+			attrs = setKeyValue(attrs, "contenteditable", "true")
+			textContent = b.editableContent()
+			hasTextContent = true
+		case "checked":
+			if b.boolValue() {
+				attrs = setKeyValue(attrs, "checked", "")
+			}
+		case "radio":
+			if value, ok := attrValue(el.attrs, "value"); ok && value == b.stringValue() {
+				attrs = setKeyValue(attrs, "checked", "")
+			}
+		case "select":
+			selected = []string{b.stringValue()}
+		case "selectmulti":
+			selected = fmt.Convert(b.stringValue()).Split()
+		case "style":
+			styles = setKeyValue(styles, b.name, b.stringValue())
+		case "classname":
+			classes = append(classes[:len(classes):len(classes)], fmt.Convert(b.stringValue()).Split()...)
+		case "prop":
+			if attr, ok := propAttrName(b.name); ok {
+				attrs = setKeyValue(attrs, attr, b.stringValue())
+			}
+		case "propbool":
+			if attr, ok := propAttrName(b.name); ok && b.boolValue() {
+				attrs = setKeyValue(attrs, attr, "")
+			}
 		}
 	}
+	attrs = mergeStyle(attrs, styles)
 
 	if len(classes) > 0 {
 		s += " class='"
@@ -388,11 +736,14 @@ func elementToHTML(el *Element) string {
 		for _, child := range el.children {
 			switch v := child.(type) {
 			case *Element:
-				s += elementToHTML(v)
+				if selected != nil {
+					v = markSelected(v, selected)
+				}
+				s += elementToHTMLWith(v, childHTML)
 			case string:
 				s += v
 			case Component:
-				s += v.String()
+				s += childHTML(v)
 			default:
 				s += fmt.Sprint(v)
 			}
@@ -401,3 +752,159 @@ func elementToHTML(el *Element) string {
 	s += "</" + el.tag + ">"
 	return s
 }
+
+// cssVarName normalizes a custom property name to its "--" form.
+func cssVarName(name string) string {
+	if len(name) >= 2 && name[0] == '-' && name[1] == '-' {
+		return name
+	}
+	return "--" + name
+}
+
+// setKeyValue replaces key's value in kv, or appends the pair.
+func setKeyValue(kv []fmt.KeyValue, key, value string) []fmt.KeyValue {
+	for i, item := range kv {
+		if item.Key == key {
+			kv[i].Value = value
+			return kv
+		}
+	}
+	return append(kv, fmt.KeyValue{Key: key, Value: value})
+}
+
+// attrValue looks key up in attrs.
+func attrValue(attrs []fmt.KeyValue, key string) (string, bool) {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// markSelected returns an <option> whose value is in values as a copy carrying
+// selected, and an <optgroup> as a copy with its options marked the same way.
+// The copy keeps the original's id (minting it first if the option will need
+// one), so events and bindings wired on the original still find the node.
+func markSelected(el *Element, values []string) *Element {
+	switch el.tag {
+	case "option":
+		value, ok := attrValue(el.attrs, "value")
+		if !ok || !containsString(values, value) {
+			return el
+		}
+		if el.needsID() {
+			el.GetID()
+		}
+		cp := *el
+		cp.attrs = setKeyValue(append([]fmt.KeyValue(nil), el.attrs...), "selected", "")
+		return &cp
+	case "optgroup":
+		cp := *el
+		cp.children = make([]any, len(el.children))
+		for i, child := range el.children {
+			if opt, ok := child.(*Element); ok {
+				child = markSelected(opt, values)
+			}
+			cp.children[i] = child
+		}
+		return &cp
+	}
+	return el
+}
+
+// containsString reports whether list holds s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// removeKeyValue returns kv without key.
+func removeKeyValue(kv []fmt.KeyValue, key string) []fmt.KeyValue {
+	var out []fmt.KeyValue
+	for _, item := range kv {
+		if item.Key != key {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// mergeStyle folds the style properties into one style attribute, after any
+// raw Attr("style", ...) text. Properties with an empty value are skipped, the
+// same as removeProperty does live, and so is any declaration whose name or
+// value carries ';', '{' or '}' outside quotes and parentheses — those would
+// end it early and smuggle in declarations of their own, where setProperty
+// would simply reject the value.
+func mergeStyle(attrs []fmt.KeyValue, styles []fmt.KeyValue) []fmt.KeyValue {
+	decl := ""
+	for _, st := range styles {
+		if st.Value == "" || !safeDecl(st.Key) || !safeDecl(st.Value) {
+			continue
+		}
+		decl += st.Key + ":" + st.Value + ";"
+	}
+	if decl == "" {
+		return attrs
+	}
+	out := make([]fmt.KeyValue, 0, len(attrs)+1)
+	found := false
+	for _, a := range attrs {
+		if a.Key == "style" {
+			raw := a.Value
+			if raw != "" && raw[len(raw)-1] != ';' {
+				raw += ";"
+			}
+			a.Value = raw + decl
+			found = true
+		}
+		out = append(out, a)
+	}
+	if !found {
+		out = append(out, fmt.KeyValue{Key: "style", Value: decl})
+	}
+	return out
+}
+
+// safeDecl reports whether s can sit inside one style declaration without
+// ending it or opening a block: ';', '{' and '}' are only allowed inside a
+// quoted string or parentheses — url("data:image/svg+xml;base64,…"), a quoted
+// font name — and every quote and parenthesis must close, or it would carry
+// the declarations that follow with it. A trailing backslash is refused for
+// the same reason.
+func safeDecl(s string) bool {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i == len(s)-1 {
+			return false // would escape the ';' that ends the declaration
+		}
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth == 0 {
+				return false
+			}
+			depth--
+		case c == '\\':
+			i++
+		case depth == 0 && (c == ';' || c == '{' || c == '}'):
+			return false
+		}
+	}
+	return quote == 0 && depth == 0
+}
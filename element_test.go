@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	tfmt "github.com/tinywasm/fmt"
 )
 
 func TestElement_ImplementsStringer(t *testing.T) {
@@ -21,3 +23,219 @@ func TestElement_String_Basic(t *testing.T) {
 		t.Error("expected text")
 	}
 }
+
+func TestElement_StyleMergesIntoOneAttribute(t *testing.T) {
+	color := NewString("red")
+	el := NewElement("div").
+		Attr("style", "margin:0").
+		Style("display", "none").
+		BindStyle("color", color).
+		BindStyleFunc("width", func() string { return "" }). // empty: omitted
+		Style("display", "block")                            // replaces, keeps order
+	got := el.String()
+	want := "<div style='margin:0;display:block;color:red;'></div>"
+	if got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+	if strings.Count(got, "style=") != 1 {
+		t.Error("expected exactly one style attribute")
+	}
+}
+
+func TestElement_StyleValueIsEscaped(t *testing.T) {
+	got := NewElement("div").Style("font-family", `"A'B"`).String()
+	if !strings.Contains(got, "style='font-family:&quot;A&#39;B&quot;;'") {
+		t.Errorf("style value not attribute-escaped: %s", got)
+	}
+}
+
+func TestElement_StyleDropsDeclarationBreakers(t *testing.T) {
+	got := NewElement("div").
+		Style("color", "red;background:url(x)").
+		Style("width{", "1px").
+		Style("height", "2px}").
+		Style("margin", "0").
+		String()
+	if got != "<div style='margin:0;'></div>" {
+		t.Errorf("declarations carrying ; { or } must be dropped, got %s", got)
+	}
+}
+
+func TestElement_StyleKeepsQuotedAndParenthesizedSeparators(t *testing.T) {
+	svg := `url("data:image/svg+xml;charset=utf-8,<svg/>")`
+	png := "url(data:image/png;base64,iVBORw0KGgo=)"
+	got := NewElement("div").
+		Style("background-image", svg).
+		Style("mask-image", png).
+		Style("font-family", `"Semi;Colon {Sans}", serif`).
+		Style("content", `"open`).  // unclosed quote: dropped
+		Style("width", "calc(1px"). // unclosed parenthesis: dropped
+		Style("height", `1px\`).    // trailing backslash: dropped
+		String()
+	for _, want := range []string{
+		"background-image:url(&quot;data:image/svg+xml;charset=utf-8,&lt;svg/&gt;&quot;);",
+		"mask-image:url(data:image/png;base64,iVBORw0KGgo=);",
+		"font-family:&quot;Semi;Colon {Sans}&quot;, serif;",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in %s", want, got)
+		}
+	}
+	for _, gone := range []string{"content:", "width:", "height:"} {
+		if strings.Contains(got, gone) {
+			t.Errorf("%s should have been dropped: %s", gone, got)
+		}
+	}
+}
+
+func TestElement_BindCSSVarNormalizesName(t *testing.T) {
+	got := NewElement("div").
+		BindCSSVar("accent", NewString("red")).
+		BindCSSVarFunc("--gap", func() string { return "2px" }).
+		String()
+	want := "<div style='--accent:red;--gap:2px;'></div>"
+	if got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestElement_BindPropSSRWritesReflectedAttributesOnly(t *testing.T) {
+	got := NewElement("details").
+		BindPropBool("open", NewBool(true)).
+		BindPropBool("indeterminate", NewBool(true)). // no attribute form
+		BindProp("scrollTop", NewString("40")).       // no attribute form
+		BindPropFunc("title", func() string { return "t" }).
+		BindPropBoolFunc("readOnly", func() bool { return true }).
+		String()
+	want := "<details open='' title='t' readonly=''></details>"
+	if got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestElement_BindClassNameSSR(t *testing.T) {
+	got := NewElement("button").Class("btn").
+		BindClassName(NewString("size-lg  theme-dark")).
+		BindClassNameFunc(func() string { return "" }).
+		String()
+	want := "<button class='btn size-lg theme-dark'></button>"
+	if got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestElement_BindAttrOptRemovesEmptyAttribute(t *testing.T) {
+	src := NewString("")
+	el := NewElement("img").NoCloseTag().Attr("src", "/placeholder.png").BindAttrOpt("src", src).
+		BindAttrOptFunc("aria-describedby", func() string { return "" })
+	if got := el.String(); got != "<img>" {
+		t.Errorf("empty optional attrs must be absent, got %s", got)
+	}
+	src.Set("/a.png")
+	if got := el.String(); got != "<img src='/a.png'>" {
+		t.Errorf("want src written, got %s", got)
+	}
+	// The builder's own attrs are untouched by serialization.
+	if len(el.attrs) != 1 || el.attrs[0].Value != "/placeholder.png" {
+		t.Errorf("serialization mutated the builder: %v", el.attrs)
+	}
+}
+
+func TestElement_FormBindingsSSR(t *testing.T) {
+	option := func(v string) *Element { return NewElement("option").Attr("value", v).Text(v) }
+
+	if got := NewElement("input").NoCloseTag().BindChecked(NewBool(true)).String(); got != "<input checked=''>" {
+		t.Errorf("BindChecked: got %s", got)
+	}
+
+	size := NewString("m")
+	for v, want := range map[string]string{
+		"s": "<input value='s'>",
+		"m": "<input value='m' checked=''>",
+	} {
+		if got := NewElement("input").NoCloseTag().Attr("value", v).BindRadio(size).String(); got != want {
+			t.Errorf("BindRadio(%s): want %s, got %s", v, want, got)
+		}
+	}
+
+	got := NewElement("select").BindSelect(size).Child(option("s"), option("m")).String()
+	want := "<select><option value='s'>s</option><option value='m' selected=''>m</option></select>"
+	if got != want {
+		t.Errorf("BindSelect: want %s, got %s", want, got)
+	}
+
+	multi := NewElement("select").Attr("multiple", "").BindSelectMulti(NewString("a c")).
+		Child(option("a"), NewElement("optgroup").Child(option("b"), option("c")))
+	got = multi.String()
+	for _, sel := range []string{"<option value='a' selected=''>", "<option value='c' selected=''>", "<option value='b'>"} {
+		if !strings.Contains(got, sel) {
+			t.Errorf("BindSelectMulti: missing %s in %s", sel, got)
+		}
+	}
+	// Marking is done on copies: the builder's options are untouched.
+	if strings.Contains(option("a").String(), "selected") || strings.Contains(multi.children[0].(*Element).String(), "selected") {
+		t.Error("select serialization mutated the option elements")
+	}
+}
+
+func TestElement_BindValueSSRMatchesBind(t *testing.T) {
+	s := NewString("q")
+	plain := NewElement("input").NoCloseTag().Bind(s).String()
+	opts := NewElement("input").NoCloseTag().BindValue(s, BindOnChange|BindDeferComposition|BindForceWrite).String()
+	if plain != opts || plain != "<input value='q'>" {
+		t.Errorf("options must not change the markup: %s vs %s", plain, opts)
+	}
+}
+
+func TestElement_BindWithSSRShowsFormattedValue(t *testing.T) {
+	price := NewString("1234.5")
+	format := func(v string) string { return "$" + v }
+	got := NewElement("input").NoCloseTag().BindWith(price, format, nil, nil).String()
+	if got != "<input value='$1234.5'>" {
+		t.Errorf("got %s", got)
+	}
+	if got := NewElement("input").NoCloseTag().BindWith(price, nil, nil, nil).String(); got != "<input value='1234.5'>" {
+		t.Errorf("nil format must pass through, got %s", got)
+	}
+}
+
+func TestElement_BindEditableSSR(t *testing.T) {
+	got := NewElement("div").BindEditable(NewString("a <b>")).String()
+	if got != "<div contenteditable='true'>a &lt;b&gt;</div>" {
+		t.Errorf("plain text must be escaped, got %s", got)
+	}
+
+	strip := func(h string) string { return tfmt.ReplaceAll(h, "<script>", "") }
+	got = NewElement("div").BindEditableHTML(NewString("<b>x</b><script>"), strip).String()
+	if got != "<div contenteditable='true'><b>x</b></div>" {
+		t.Errorf("html must pass through the sanitizer, got %s", got)
+	}
+}
+
+func TestElement_BindEditableHTMLNeedsASanitizer(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("a nil sanitizer must not pass silently")
+		}
+		if msg := r.(error).Error(); !strings.Contains(msg, "sanitize") {
+			t.Errorf("panic should name the sanitizer, got: %s", msg)
+		}
+	}()
+	NewElement("div").BindEditableHTML(NewString(""), nil)
+}
+
+func TestElement_RefIsEmptyOnBackend(t *testing.T) {
+	var r Ref
+	html := (&Element{tag: "input", void: true}).Ref(&r).String()
+	if _, ok := r.Get(); ok {
+		t.Error("a ref is only filled by the WASM engine")
+	}
+	if strings.Contains(html, "id=") {
+		t.Errorf("SSR must not mint an id for a ref: %s", html)
+	}
+	var nilRef *Ref
+	if _, ok := nilRef.Get(); ok {
+		t.Error("nil ref must report false")
+	}
+}
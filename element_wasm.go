@@ -4,6 +4,8 @@ package dom
 
 import (
 	"syscall/js"
+
+	"github.com/tinywasm/fmt"
 )
 
 // elementWasm is the WASM implementation of the Reference interface.
@@ -50,20 +52,35 @@ func (e *elementWasm) Checked() bool {
 
 // On registers a generic event handler.
 func (e *elementWasm) On(eventType string, handler func(event Event)) {
-	eventKey := e.id + "::" + eventType
-	fn := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		evt := eventWasm{Value: args[0]}
-		handler(&evt)
-		return nil
-	})
-	e.val.Call("addEventListener", eventType, fn)
+	e.OnWith(eventType, ListenerOptions{}, handler)
+}
 
-	// Append to eventFuncs
-	e.dom.eventFuncs = append(e.dom.eventFuncs, struct {
-		key string
-		val js.Value
-		fn  js.Func
-	}{eventKey, e.val, fn})
+// OnWith registers an event handler with listener options. The options are
+// recorded with the entry so cleanupListeners removes it in the right phase;
+// a listener with options also gets an AbortController, whose signal is the
+// removal path that does not depend on matching the options.
+//
+// A plain listener for an event that bubbles is delegated instead: no js.Func
+// of its own, just an entry the root listener dispatches to (see delegate).
+//
+// The returned function removes this one listener early; other listeners on
+// the element are untouched, and calling it again, or after the component's
+// cleanup, does nothing.
+func (e *elementWasm) OnWith(eventType string, opts ListenerOptions, handler func(event Event)) (remove func()) {
+	// A reference without an id (an event target) still needs a key of its
+	// own, or cleanup would remove every id-less listener of that type.
+	keyID := e.id
+	if keyID == "" {
+		keyID = generateID()
+	}
+	eventKey := keyID + "::" + eventType
+	delegated := false
+	if opts == (ListenerOptions{}) {
+		remove, delegated = e.dom.delegate(e, eventType, eventKey, handler)
+	}
+	if !delegated {
+		remove = e.listen(eventType, eventKey, opts, handler)
+	}
 
 	// Associate the event with the component currently being mounted.
 	if e.dom.currentComponentID != "" {
@@ -83,6 +100,67 @@ func (e *elementWasm) On(eventType string, handler func(event Event)) {
 			}{compID, []string{eventKey}})
 		}
 	}
+	return remove
+}
+
+// Dispatch fires a bubbling, composed CustomEvent with a string detail.
+func (e *elementWasm) Dispatch(name, detail string) {
+	e.dispatch(name, js.ValueOf(detail))
+}
+
+// DispatchKV fires a bubbling, composed CustomEvent whose detail is an object
+// built from the pairs.
+func (e *elementWasm) DispatchKV(name string, detail ...fmt.KeyValue) {
+	o := js.Global().Get("Object").New()
+	for _, kv := range detail {
+		o.Set(kv.Key, kv.Value)
+	}
+	e.dispatch(name, o)
+}
+
+func (e *elementWasm) dispatch(name string, detail js.Value) {
+	init := js.Global().Get("Object").New()
+	init.Set("bubbles", true)
+	init.Set("composed", true)
+	init.Set("detail", detail)
+	e.val.Call("dispatchEvent", js.Global().Get("CustomEvent").New(name, init))
+}
+
+// listen adds a listener of its own to the element and returns its remover.
+// A Once listener removes itself after it runs: the browser has already
+// dropped it, and its js.Func would otherwise live until the component goes.
+func (e *elementWasm) listen(eventType, eventKey string, opts ListenerOptions, handler func(event Event)) (remove func()) {
+	var fn js.Func
+	fn = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		evt := eventWasm{Value: args[0], dom: e.dom}
+		handler(&evt)
+		if opts.Once {
+			e.dom.unlisten(fn)
+		}
+		return nil
+	})
+	var abort js.Value
+	if opts == (ListenerOptions{}) {
+		e.val.Call("addEventListener", eventType, fn)
+	} else {
+		abort = js.Global().Get("AbortController").New()
+		o := js.Global().Get("Object").New()
+		o.Set("once", opts.Once)
+		o.Set("passive", opts.Passive)
+		o.Set("capture", opts.Capture)
+		o.Set("signal", abort.Get("signal"))
+		e.val.Call("addEventListener", eventType, fn, o)
+	}
+
+	// Append to eventFuncs
+	e.dom.eventFuncs = append(e.dom.eventFuncs, struct {
+		key     string
+		val     js.Value
+		fn      js.Func
+		capture bool
+		abort   js.Value
+	}{eventKey, e.val, fn, opts.Capture, abort})
+	return func() { e.dom.unlisten(fn) }
 }
 
 // Focus sets focus to the element.
@@ -122,3 +200,81 @@ func (e *elementWasm) ScrollIntoView() {
 	opts.Set("block", "nearest")
 	e.val.Call("scrollIntoView", opts)
 }
+
+// BoundingRect returns getBoundingClientRect.
+func (e *elementWasm) BoundingRect() Rect {
+	r := e.val.Call("getBoundingClientRect")
+	return Rect{
+		X:      r.Get("x").Float(),
+		Y:      r.Get("y").Float(),
+		Width:  r.Get("width").Float(),
+		Height: r.Get("height").Float(),
+	}
+}
+
+// ScrollTop returns element.scrollTop.
+func (e *elementWasm) ScrollTop() float64 { return e.val.Get("scrollTop").Float() }
+
+// ScrollLeft returns element.scrollLeft.
+func (e *elementWasm) ScrollLeft() float64 { return e.val.Get("scrollLeft").Float() }
+
+// SetScrollTop sets element.scrollTop.
+func (e *elementWasm) SetScrollTop(v float64) { e.val.Set("scrollTop", v) }
+
+// SetScrollLeft sets element.scrollLeft.
+func (e *elementWasm) SetScrollLeft(v float64) { e.val.Set("scrollLeft", v) }
+
+// ScrollHeight returns element.scrollHeight.
+func (e *elementWasm) ScrollHeight() float64 { return e.val.Get("scrollHeight").Float() }
+
+// ScrollWidth returns element.scrollWidth.
+func (e *elementWasm) ScrollWidth() float64 { return e.val.Get("scrollWidth").Float() }
+
+// AddClass calls classList.add.
+func (e *elementWasm) AddClass(name string) { e.val.Get("classList").Call("add", name) }
+
+// RemoveClass calls classList.remove.
+func (e *elementWasm) RemoveClass(name string) { e.val.Get("classList").Call("remove", name) }
+
+// HasClass calls classList.contains.
+func (e *elementWasm) HasClass(name string) bool {
+	return e.val.Get("classList").Call("contains", name).Bool()
+}
+
+// ToggleClass calls classList.toggle.
+func (e *elementWasm) ToggleClass(name string) bool {
+	return e.val.Get("classList").Call("toggle", name).Bool()
+}
+
+// ComputedStyle reads getComputedStyle(element).getPropertyValue(property).
+func (e *elementWasm) ComputedStyle(property string) string {
+	return js.Global().Call("getComputedStyle", e.val).Call("getPropertyValue", property).String()
+}
+
+// Query calls querySelector on the element.
+func (e *elementWasm) Query(selector string) (Reference, bool) {
+	v := query(e.val, "querySelector", selector)
+	if !v.Truthy() {
+		return nil, false
+	}
+	return e.dom.wrap(v), true
+}
+
+// QueryAll calls querySelectorAll on the element.
+func (e *elementWasm) QueryAll(selector string) []Reference {
+	return e.dom.wrapList(query(e.val, "querySelectorAll", selector))
+}
+
+// Parent returns parentElement.
+func (e *elementWasm) Parent() (Reference, bool) {
+	v := e.val.Get("parentElement")
+	if !v.Truthy() {
+		return nil, false
+	}
+	return e.dom.wrap(v), true
+}
+
+// Children returns the element children.
+func (e *elementWasm) Children() []Reference {
+	return e.dom.wrapList(e.val.Get("children"))
+}
@@ -1,5 +1,7 @@
 package dom
 
+import "github.com/tinywasm/fmt"
+
 // Event represents a DOM event.
 type Event interface {
 	// PreventDefault prevents the default action of the event.
@@ -14,4 +16,108 @@ type Event interface {
 	// TargetChecked returns the checked status of the event's target element.
 	// Useful for checkbox and radio input elements.
 	TargetChecked() bool
+
+	// Type returns the event name ("click", "keydown", ...), so one handler
+	// can serve several events.
+	Type() string
+	// Target returns the element the event was dispatched to, or nil when it
+	// was not an element (window, document).
+	Target() Reference
+	// CurrentTarget returns the element whose handler is running — the one On
+	// was called on, not the descendant that was clicked.
+	CurrentTarget() Reference
+
+	// --- Keyboard ---
+
+	// Key returns the produced value ("a", "A", "Enter", "ArrowUp"); it
+	// follows the keyboard layout and Shift. Use it for text and shortcuts.
+	Key() string
+	// Code returns the physical key ("KeyA", "Digit1"), the same on every
+	// layout. Use it for game-style controls.
+	Code() string
+	// AltKey, CtrlKey, ShiftKey and MetaKey report the modifiers held during
+	// keyboard, mouse and pointer events.
+	AltKey() bool
+	CtrlKey() bool
+	ShiftKey() bool
+	MetaKey() bool
+
+	// --- Mouse, pointer and wheel ---
+
+	// ClientX and ClientY return the pointer position relative to the viewport.
+	ClientX() float64
+	ClientY() float64
+	// Button returns which button changed state: 0 main, 1 middle, 2 secondary.
+	Button() int
+	// Buttons returns the buttons held down as a bit mask: 1 main, 2
+	// secondary, 4 middle.
+	Buttons() int
+	// DeltaX and DeltaY return the wheel scroll amounts.
+	DeltaX() float64
+	DeltaY() float64
+
+	// --- Custom events ---
+
+	// Detail returns the payload of a custom event sent with Reference.Dispatch.
+	Detail() string
+	// DetailValue returns one value of a payload sent with DispatchKV.
+	DetailValue(key string) string
+}
+
+// SyntheticEvent is a plain Go Event, usable on the backend: handlers can be
+// unit-tested without a browser by filling the fields they read, calling the
+// handler, then checking Prevented and Stopped. Accessors of events that do
+// not carry a value (Key on a click) return the zero value, as in the browser.
+type SyntheticEvent struct {
+	Name                        string // Type
+	KeyName, CodeName           string // Key, Code
+	Alt, Ctrl, Shift, Meta      bool
+	X, Y                        float64        // ClientX, ClientY
+	ButtonIndex, ButtonMask     int            // Button, Buttons
+	WheelX, WheelY              float64        // DeltaX, DeltaY
+	Value, ID                   string         // TargetValue, TargetID
+	IsChecked                   bool           // TargetChecked
+	DetailText                  string         // Detail
+	DetailKV                    []fmt.KeyValue // DetailValue
+	TargetRef, CurrentTargetRef Reference
+	Prevented, Stopped          bool // set by PreventDefault, StopPropagation
+}
+
+func (e *SyntheticEvent) PreventDefault()          { e.Prevented = true }
+func (e *SyntheticEvent) StopPropagation()         { e.Stopped = true }
+func (e *SyntheticEvent) TargetValue() string      { return e.Value }
+func (e *SyntheticEvent) TargetID() string         { return e.ID }
+func (e *SyntheticEvent) TargetChecked() bool      { return e.IsChecked }
+func (e *SyntheticEvent) Type() string             { return e.Name }
+func (e *SyntheticEvent) Target() Reference        { return e.TargetRef }
+func (e *SyntheticEvent) CurrentTarget() Reference { return e.CurrentTargetRef }
+func (e *SyntheticEvent) Key() string              { return e.KeyName }
+func (e *SyntheticEvent) Code() string             { return e.CodeName }
+func (e *SyntheticEvent) AltKey() bool             { return e.Alt }
+func (e *SyntheticEvent) CtrlKey() bool            { return e.Ctrl }
+func (e *SyntheticEvent) ShiftKey() bool           { return e.Shift }
+func (e *SyntheticEvent) MetaKey() bool            { return e.Meta }
+func (e *SyntheticEvent) ClientX() float64         { return e.X }
+func (e *SyntheticEvent) ClientY() float64         { return e.Y }
+func (e *SyntheticEvent) Button() int              { return e.ButtonIndex }
+func (e *SyntheticEvent) Buttons() int             { return e.ButtonMask }
+func (e *SyntheticEvent) DeltaX() float64          { return e.WheelX }
+func (e *SyntheticEvent) DeltaY() float64          { return e.WheelY }
+func (e *SyntheticEvent) Detail() string           { return e.DetailText }
+func (e *SyntheticEvent) DetailValue(key string) string {
+	v, _ := attrValue(e.DetailKV, key)
+	return v
+}
+
+// ListenerOptions are the addEventListener options. The zero value is a plain
+// bubble-phase listener, what On registers.
+type ListenerOptions struct {
+	// Once removes the listener after its first call.
+	Once bool
+	// Passive promises the handler never calls PreventDefault, which lets the
+	// browser scroll without waiting for it — touchstart, touchmove, wheel.
+	Passive bool
+	// Capture runs the handler on the way down instead of while bubbling, so
+	// it sees events that do not bubble (scroll, focus) from any descendant.
+	Capture bool
 }
@@ -0,0 +1,39 @@
+package dom
+
+import "testing"
+
+func TestSyntheticEventDrivesHandler(t *testing.T) {
+	var _ Event = &SyntheticEvent{} // compile-time check
+
+	// A handler as a component would write it: Ctrl+S saves, anything else
+	// passes through to the browser.
+	saved := false
+	handler := func(e Event) {
+		if e.Type() == "keydown" && e.CtrlKey() && e.Key() == "s" {
+			e.PreventDefault()
+			saved = true
+		}
+	}
+
+	plain := &SyntheticEvent{Name: "keydown", KeyName: "s"}
+	handler(plain)
+	if saved || plain.Prevented {
+		t.Fatal("plain 's' must not trigger the save shortcut")
+	}
+
+	ctrl := &SyntheticEvent{Name: "keydown", KeyName: "s", CodeName: "KeyS", Ctrl: true}
+	handler(ctrl)
+	if !saved || !ctrl.Prevented {
+		t.Error("Ctrl+S must save and prevent the browser's save dialog")
+	}
+}
+
+func TestSyntheticEventZeroValues(t *testing.T) {
+	e := &SyntheticEvent{Name: "click", X: 10, Y: 20, ButtonIndex: 2}
+	if e.Key() != "" || e.DeltaY() != 0 || e.Target() != nil {
+		t.Error("fields a click does not carry must read as zero")
+	}
+	if e.ClientX() != 10 || e.ClientY() != 20 || e.Button() != 2 {
+		t.Errorf("pointer data: got (%v,%v) button %d", e.ClientX(), e.ClientY(), e.Button())
+	}
+}
@@ -9,6 +9,9 @@ import (
 // eventWasm is the WASM implementation of the Event interface.
 type eventWasm struct {
 	js.Value
+	dom     *domWasm
+	current js.Value // element whose delegated handlers run; undefined when listened directly
+	stopped bool     // StopPropagation was called: delegated dispatch stops climbing
 }
 
 // PreventDefault prevents the default action of the event.
@@ -18,6 +21,7 @@ func (e *eventWasm) PreventDefault() {
 
 // StopPropagation stops the event from bubbling up the DOM tree.
 func (e *eventWasm) StopPropagation() {
+	e.stopped = true
 	e.Call("stopPropagation")
 }
 
@@ -47,3 +51,101 @@ func (e *eventWasm) TargetChecked() bool {
 	}
 	return v.Bool()
 }
+
+// Type returns the event name.
+func (e *eventWasm) Type() string { return e.str("type") }
+
+// Target returns the element the event was dispatched to.
+func (e *eventWasm) Target() Reference { return e.ref(e.Get("target")) }
+
+// CurrentTarget returns the element whose handler is running. Delegated
+// handlers all run from the root listener, so the native currentTarget would
+// be the root: dispatch records the element instead.
+func (e *eventWasm) CurrentTarget() Reference {
+	if e.current.Truthy() {
+		return e.ref(e.current)
+	}
+	return e.ref(e.Get("currentTarget"))
+}
+
+// Key returns the produced key value.
+func (e *eventWasm) Key() string { return e.str("key") }
+
+// Code returns the physical key.
+func (e *eventWasm) Code() string { return e.str("code") }
+
+// AltKey reports whether Alt was held.
+func (e *eventWasm) AltKey() bool { return e.flag("altKey") }
+
+// CtrlKey reports whether Control was held.
+func (e *eventWasm) CtrlKey() bool { return e.flag("ctrlKey") }
+
+// ShiftKey reports whether Shift was held.
+func (e *eventWasm) ShiftKey() bool { return e.flag("shiftKey") }
+
+// MetaKey reports whether Meta (⌘ / Windows key) was held.
+func (e *eventWasm) MetaKey() bool { return e.flag("metaKey") }
+
+// ClientX returns the horizontal viewport position.
+func (e *eventWasm) ClientX() float64 { return e.num("clientX") }
+
+// ClientY returns the vertical viewport position.
+func (e *eventWasm) ClientY() float64 { return e.num("clientY") }
+
+// Button returns the button that changed state.
+func (e *eventWasm) Button() int { return int(e.num("button")) }
+
+// Buttons returns the mask of buttons held down.
+func (e *eventWasm) Buttons() int { return int(e.num("buttons")) }
+
+// DeltaX returns the horizontal wheel amount.
+func (e *eventWasm) DeltaX() float64 { return e.num("deltaX") }
+
+// DeltaY returns the vertical wheel amount.
+func (e *eventWasm) DeltaY() float64 { return e.num("deltaY") }
+
+// Detail returns a custom event's string payload.
+func (e *eventWasm) Detail() string { return e.str("detail") }
+
+// DetailValue returns one value of a custom event's key/value payload.
+func (e *eventWasm) DetailValue(key string) string {
+	d := e.Get("detail")
+	if d.Type() != js.TypeObject {
+		return ""
+	}
+	if v := d.Get(key); v.Type() == js.TypeString {
+		return v.String()
+	}
+	return ""
+}
+
+// str, flag and num read a property that only some event types carry; on the
+// others it is undefined, and js.Value would panic converting it.
+func (e *eventWasm) str(name string) string {
+	if v := e.Get(name); v.Type() == js.TypeString {
+		return v.String()
+	}
+	return ""
+}
+
+func (e *eventWasm) flag(name string) bool {
+	if v := e.Get(name); v.Type() == js.TypeBoolean {
+		return v.Bool()
+	}
+	return false
+}
+
+func (e *eventWasm) num(name string) float64 {
+	if v := e.Get(name); v.Type() == js.TypeNumber {
+		return v.Float()
+	}
+	return 0
+}
+
+// ref wraps an event node as a Reference; nil for anything but an element.
+func (e *eventWasm) ref(v js.Value) Reference {
+	if !v.Truthy() || v.Get("nodeType").Int() != 1 {
+		return nil
+	}
+	return e.dom.wrap(v)
+}
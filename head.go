@@ -0,0 +1,55 @@
+package dom
+
+// headEntry is one piece of document head state: the title, a <meta name> or a
+// <link rel>. Entries that share kind and name compete for the same tag and the
+// last one registered wins; value is the static form (Document), signal the
+// reactive one (Title/Meta/Link).
+type headEntry struct {
+	kind   string // "title", "meta", "link"
+	name   string // meta name or link rel; "" for the title
+	value  string
+	signal *SignalString
+}
+
+func (h headEntry) get() string {
+	if h.signal != nil {
+		return h.signal.Get()
+	}
+	return h.value
+}
+
+func (h headEntry) sameTag(o headEntry) bool {
+	return h.kind == o.kind && h.name == o.name
+}
+
+// Title binds document.title to s.
+//
+// Head bindings belong to the component whose Init Ctx registers them:
+// unmounting it reverts the tag to what the previous owner (or the page) had,
+// and while several mounted components bind the same tag the last one mounted
+// wins — a detail view opened over a list shows its own title and gives the
+// list's back when it closes. With a nil ctx the binding lives as long as the
+// page. On the backend the bindings are rendered into the <head> of the
+// Document whose render handed out ctx; with any other ctx the server ignores
+// them.
+func Title(ctx Ctx, s *SignalString) {
+	bindHead(ctx, headEntry{kind: "title", signal: s})
+}
+
+// Meta binds the content of <meta name=name> to s, creating the tag if the page
+// has none. See Title for ownership.
+func Meta(ctx Ctx, name string, s *SignalString) {
+	bindHead(ctx, headEntry{kind: "meta", name: name, signal: s})
+}
+
+// Link binds the href of <link rel=rel> to s, creating the tag if the page has
+// none. One tag per rel: use it for canonical, alternate, icon and the like,
+// not for a list of stylesheets. See Title for ownership.
+func Link(ctx Ctx, rel string, s *SignalString) {
+	bindHead(ctx, headEntry{kind: "link", name: rel, signal: s})
+}
+
+// Canonical binds <link rel="canonical">.
+func Canonical(ctx Ctx, s *SignalString) {
+	Link(ctx, "canonical", s)
+}
@@ -0,0 +1,26 @@
+//go:build !wasm
+
+package dom
+
+import "github.com/tinywasm/fmt"
+
+// bindHead records the entry on the Document ctx belongs to, like
+// registerTransfer; without a page it is dropped.
+func bindHead(ctx Ctx, h headEntry) {
+	if p := pageOf(ctx); p != nil {
+		p.head = append(p.head, h)
+	}
+}
+
+// headToHTML serializes one head entry.
+func headToHTML(h headEntry) string {
+	switch h.kind {
+	case "title":
+		return "<title>" + fmt.Convert(h.get()).EscapeHTML() + "</title>"
+	case "meta":
+		return NewElement("meta").NoCloseTag().Attr("name", h.name).Attr("content", h.get()).String()
+	case "link":
+		return NewElement("link").NoCloseTag().Attr("rel", h.name).Attr("href", h.get()).String()
+	}
+	return ""
+}
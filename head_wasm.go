@@ -0,0 +1,127 @@
+//go:build wasm
+
+package dom
+
+import "syscall/js"
+
+// The head is one per page, so its state is package-level: headBound in
+// registration order (the last entry per tag is the one shown), headSaved what
+// the page had before the first binding of each tag, for the revert.
+var (
+	headBound []*headEntry
+	headSaved []struct {
+		tag     headEntry
+		existed bool
+	}
+)
+
+func bindHead(ctx Ctx, h headEntry) {
+	e := &h
+	saveHead(e)
+	headBound = append(headBound, e)
+
+	unsub := e.signal.subscribe(func() {
+		if topHead(e) == e {
+			writeHead(e.kind, e.name, e.get())
+		}
+	})
+	writeHead(e.kind, e.name, e.get())
+
+	if ctx == nil {
+		return
+	}
+	ctx.OnCleanup(func() {
+		unsub()
+		dropHead(e)
+	})
+}
+
+// topHead returns the entry that currently owns e's tag.
+func topHead(e *headEntry) *headEntry {
+	for i := len(headBound) - 1; i >= 0; i-- {
+		if headBound[i].sameTag(*e) {
+			return headBound[i]
+		}
+	}
+	return nil
+}
+
+// dropHead removes e and hands its tag to the previous owner, or back to the
+// page when nobody else binds it.
+func dropHead(e *headEntry) {
+	for i, h := range headBound {
+		if h == e {
+			headBound = append(headBound[:i], headBound[i+1:]...)
+			break
+		}
+	}
+	if top := topHead(e); top != nil {
+		writeHead(top.kind, top.name, top.get())
+		return
+	}
+	for i, s := range headSaved {
+		if s.tag.sameTag(*e) {
+			headSaved = append(headSaved[:i], headSaved[i+1:]...)
+			if s.existed {
+				writeHead(s.tag.kind, s.tag.name, s.tag.value)
+			} else if el := headTag(s.tag.kind, s.tag.name, false); el.Truthy() {
+				el.Call("remove")
+			}
+			return
+		}
+	}
+}
+
+// saveHead records the page's own value for e's tag the first time it is bound.
+func saveHead(e *headEntry) {
+	for _, s := range headSaved {
+		if s.tag.sameTag(*e) {
+			return
+		}
+	}
+	tag := headEntry{kind: e.kind, name: e.name}
+	existed := false
+	if e.kind == "title" {
+		existed = true
+		tag.value = js.Global().Get("document").Get("title").String()
+	} else if el := headTag(e.kind, e.name, false); el.Truthy() {
+		existed = true
+		tag.value = el.Call("getAttribute", headValueAttr(e.kind)).String()
+	}
+	headSaved = append(headSaved, struct {
+		tag     headEntry
+		existed bool
+	}{tag, existed})
+}
+
+// writeHead sets the tag's value, creating the tag when the page lacks it.
+func writeHead(kind, name, value string) {
+	if kind == "title" {
+		js.Global().Get("document").Set("title", value)
+		return
+	}
+	headTag(kind, name, true).Call("setAttribute", headValueAttr(kind), value)
+}
+
+// headTag finds <meta name> / <link rel> in document.head, optionally creating it.
+func headTag(kind, name string, create bool) js.Value {
+	doc := js.Global().Get("document")
+	key := "name"
+	if kind == "link" {
+		key = "rel"
+	}
+	el := doc.Get("head").Call("querySelector", kind+"["+key+"=\""+js.Global().Get("CSS").Call("escape", name).String()+"\"]")
+	if (el.IsNull() || el.IsUndefined()) && create {
+		el = doc.Call("createElement", kind)
+		el.Call("setAttribute", key, name)
+		doc.Get("head").Call("appendChild", el)
+	}
+	return el
+}
+
+func headValueAttr(kind string) string {
+	if kind == "link" {
+		return "href"
+	}
+	return "content"
+}
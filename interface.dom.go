@@ -13,6 +13,35 @@ type DOM interface {
 	// Útil para listas dinámicas.
 	Append(parentID string, component Component) error
 
+	// Prepend injecta un componente ANTES del primer hijo del elemento padre.
+	// Útil para pilas de notificaciones (lo más nuevo arriba).
+	Prepend(parentID string, component Component) error
+
+	// InsertBefore injecta un componente justo antes del hijo beforeID del
+	// elemento padre.
+	InsertBefore(parentID, beforeID string, component Component) error
+
+	// Replace desmonta old y monta component en el lugar que ocupaba.
+	Replace(old, component Component) error
+
+	// AttachShadow adjunta un shadow root al elemento hostID y devuelve el id
+	// de un punto de montaje dentro de él, para Render/Append. Ver la función
+	// de paquete del mismo nombre.
+	AttachShadow(hostID string, opts ShadowOptions) (mountID string, err error)
+
+	// DefineElement registra tag como custom element cuyos componentes se
+	// montan en esta raíz. Ver la función de paquete del mismo nombre.
+	DefineElement(tag string, factory func(attrs *HostAttrs) Component, observed ...string) error
+
+	// Unmount quita un componente del DOM y ejecuta sus limpiezas (listeners,
+	// suscripciones, OnCleanup), también las de sus hijos.
+	Unmount(component Component)
+
+	// Close desmonta todo lo que montó una raíz creada con New, libera sus
+	// listeners y suscripciones y la retira de la página; después Render y
+	// las inserciones devuelven error. Sobre la raíz por defecto no hace nada.
+	Close()
+
 	// OnHashChange registra un listener para cambios en el hash de la URL.
 	OnHashChange(handler func(hash string))
 
@@ -30,6 +59,16 @@ type DOM interface {
 	// Get retrieves an element by ID.
 	Get(id string) (Reference, bool)
 
+	// Query returns the first element in the document matching a CSS
+	// selector — markup this package did not render: the assetmin sprite,
+	// third-party widgets, server-rendered islands. A selector the browser
+	// rejects finds nothing.
+	Query(selector string) (Reference, bool)
+
+	// QueryAll returns every element in the document matching a CSS selector;
+	// none for a selector the browser rejects.
+	QueryAll(selector string) []Reference
+
 	// Log provides logging functionality using the log function passed to New.
 	Log(v ...any)
 }
@@ -85,5 +124,6 @@ type mountable interface {
 // eventHandler represents a DOM event handler in the declarative builder.
 type eventHandler struct {
 	Name    string
+	Opts    ListenerOptions
 	Handler func(Event)
 }
@@ -185,6 +185,52 @@ func TestBindChildren(t *testing.T) {
 	}
 }
 
+// keyedRowsComp wraps its list in a section, so the rows are owned by the list
+// element rather than the component root.
+type keyedRowsComp struct {
+	Element
+	rows *SignalNodes
+}
+
+func (c *keyedRowsComp) Render() *Element {
+	return NewElement("section").ID(c.GetID()).
+		Child(NewElement("ul").ID(c.GetID() + "-list").BindChildren(c.rows))
+}
+
+func TestKeyedRowReaddedRunsOnlyItsNewHandler(t *testing.T) {
+	d := instance.(*domWasm)
+	rootsBefore := len(delegateRoots)
+	var clicks []string
+	row := func(id, tag string) *Element {
+		return NewElement("li").ID(id).On("click", func(Event) { clicks = append(clicks, tag) })
+	}
+	c := &keyedRowsComp{rows: NewNodes()}
+	c.SetID("keyed")
+	Render("app", c)
+
+	c.rows.Set([]*Element{row("kr-a", "a1"), row("kr-b", "b1")})
+	c.rows.Set([]*Element{row("kr-a", "a1")})
+	if _, ok := d.findDelegation("kr-b::click"); ok {
+		t.Error("a removed row's delegated handler must go with it")
+	}
+
+	c.rows.Set([]*Element{row("kr-a", "a1"), row("kr-b", "b2")})
+	js.Global().Get("document").Call("getElementById", "kr-b").Call("click")
+	if len(clicks) != 1 || clicks[0] != "b2" {
+		t.Errorf("one click on the re-added row ran %v, want only [b2]", clicks)
+	}
+
+	d.Unmount(c)
+	for _, key := range []string{"kr-a::click", "kr-b::click"} {
+		if _, ok := d.findDelegation(key); ok {
+			t.Errorf("%s survived the component's unmount", key)
+		}
+	}
+	if len(delegateRoots) != rootsBefore {
+		t.Errorf("root listeners after unmount = %d, want %d", len(delegateRoots), rootsBefore)
+	}
+}
+
 // TestBindChildrenInitialRowBindings guards the fix for rows present in a
 // BindChildren signal at FIRST render: they are serialized straight into the
 // parent's HTML and never pass through reconcileChildren, so their own nested
@@ -369,3 +415,196 @@ func TestMountedScrollableConsumer(t *testing.T) {
 		t.Log("Warning: deck-scroller.ScrollsX() is false (expected in some headless environments or without style sheet support, but scroller is successfully wired)")
 	}
 }
+
+// rowsComp renders many rows with a click handler each; delegation must serve
+// them all from one root listener instead of one js.Func per row.
+type rowsComp struct {
+	Element
+	clicked string
+}
+
+func (c *rowsComp) Render() *Element {
+	list := NewElement("ul").ID(c.GetID())
+	for _, id := range []string{"row-a", "row-b", "row-c"} {
+		rowID := id
+		list.Child(NewElement("li").ID(rowID).On("click", func(e Event) { c.clicked = rowID }))
+	}
+	return list
+}
+
+func TestDelegatedClicksShareOneRootListener(t *testing.T) {
+	d := instance.(*domWasm)
+	funcsBefore, rootsBefore := len(d.eventFuncs), len(delegateRoots)
+
+	c := &rowsComp{}
+	c.SetID("rows")
+	Render("app", c)
+
+	if got := len(d.eventFuncs) - funcsBefore; got != 0 {
+		t.Errorf("rows registered %d listeners of their own, want 0", got)
+	}
+	if got := len(delegateRoots) - rootsBefore; got > 1 {
+		t.Errorf("want at most one new root listener for click, got %d", got)
+	}
+	js.Global().Get("document").Call("getElementById", "row-b").Call("click")
+	if c.clicked != "row-b" {
+		t.Errorf("delegated click reached %q, want row-b", c.clicked)
+	}
+
+	d.Unmount(c)
+	if _, ok := d.findDelegation("row-a::click"); ok {
+		t.Error("delegated handler survived unmount")
+	}
+	if len(delegateRoots) != rootsBefore {
+		t.Errorf("root listeners after unmount = %d, want %d: the click listener must be released with its last handler", len(delegateRoots), rootsBefore)
+	}
+}
+
+func TestOnceListenerReleasesItsFunc(t *testing.T) {
+	d := instance.(*domWasm)
+	before := len(d.eventFuncs)
+	c := &rowsComp{}
+	c.SetID("rows-once")
+	Render("app", c)
+	defer d.Unmount(c)
+
+	ref, _ := d.Get("row-a")
+	fired := 0
+	ref.OnWith("click", ListenerOptions{Once: true}, func(Event) { fired++ })
+	if len(d.eventFuncs) != before+1 {
+		t.Fatalf("a Once listener should hold one entry until it fires, got %d", len(d.eventFuncs)-before)
+	}
+	row := js.Global().Get("document").Call("getElementById", "row-a")
+	row.Call("click")
+	row.Call("click")
+	if fired != 1 {
+		t.Errorf("Once listener fired %d times", fired)
+	}
+	if len(d.eventFuncs) != before {
+		t.Errorf("a fired Once listener must release its entry, %d left", len(d.eventFuncs)-before)
+	}
+}
+
+func TestOnWithRemoverDropsOnlyItsListener(t *testing.T) {
+	d := instance.(*domWasm)
+	c := &rowsComp{}
+	c.SetID("rows-remove")
+	Render("app", c)
+	defer d.Unmount(c)
+
+	ref, _ := d.Get("row-b")
+	var got []string
+	removeOwn := ref.OnWith("click", ListenerOptions{Capture: true}, func(Event) { got = append(got, "own") })
+	removeDelegated := ref.OnWith("click", ListenerOptions{}, func(Event) { got = append(got, "delegated") })
+	row := js.Global().Get("document").Call("getElementById", "row-b")
+
+	removeOwn()
+	removeDelegated()
+	removeDelegated() // twice is harmless
+	row.Call("click")
+	if len(got) != 0 {
+		t.Errorf("removed listeners still ran: %v", got)
+	}
+	if c.clicked != "row-b" {
+		t.Errorf("the row's own On handler must survive its neighbours' removal, clicked=%q", c.clicked)
+	}
+}
+
+func TestCloseLeavesRootsAndReleasesListeners(t *testing.T) {
+	rootsBefore, listenersBefore := len(roots), len(delegateRoots)
+	r := New(Options{}).(*domWasm)
+	c := &rowsComp{}
+	c.SetID("closing-rows")
+	if err := r.Render("app", c); err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	if len(roots) != rootsBefore {
+		t.Errorf("roots after Close = %d, want %d", len(roots), rootsBefore)
+	}
+	if len(delegateRoots) != listenersBefore {
+		t.Errorf("root listeners after Close = %d, want %d", len(delegateRoots), listenersBefore)
+	}
+}
+
+// mountHookComp starts a "widget" on an inner element with OnMount.
+type mountHookComp struct {
+	Element
+	started, stopped int
+}
+
+func (c *mountHookComp) Render() *Element {
+	return NewElement("div").Child(NewElement("canvas").OnMount(func(Reference) func() {
+		c.started++
+		return func() { c.stopped++ }
+	}))
+}
+
+func TestOnMountCleanupFollowsOwnerLifecycle(t *testing.T) {
+	d := instance.(*domWasm)
+	c := &mountHookComp{}
+	c.SetID("hook-owner")
+	if err := Render("app", c); err != nil {
+		t.Fatal(err)
+	}
+	d.update("hook-owner")
+	if c.started != 2 || c.stopped != 1 {
+		t.Errorf("after update: started=%d stopped=%d, want 2/1", c.started, c.stopped)
+	}
+	d.Unmount(c)
+	if c.stopped != 2 {
+		t.Errorf("after unmount: stopped=%d, want 2", c.stopped)
+	}
+	for _, item := range d.detach {
+		if item.owner == "hook-owner" {
+			t.Error("teardown of an unmounted owner is still registered")
+		}
+	}
+}
+
+func TestShortcutEventCarriesTheRegisteringRoot(t *testing.T) {
+	r := New(Options{}).(*domWasm)
+	var got *domWasm
+	leave := r.enter()
+	err := GlobalShortcut(nil, "Ctrl+Shift+F9", func(e Event) { got = e.(*eventWasm).dom })
+	leave()
+	if err != nil {
+		t.Fatal(err)
+	}
+	init := js.Global().Get("Object").New()
+	init.Set("key", "F9")
+	init.Set("ctrlKey", true)
+	init.Set("shiftKey", true)
+	init.Set("bubbles", true)
+	js.Global().Get("document").Call("dispatchEvent", js.Global().Get("KeyboardEvent").New("keydown", init))
+	if got != r {
+		t.Error("the shortcut's event must belong to the root that registered it")
+	}
+	r.Close()
+	for _, sc := range shortcuts {
+		if sc.dom == r {
+			t.Error("Close must drop the shortcuts its root registered")
+		}
+	}
+}
+
+func TestTransferredValueIsReadOnce(t *testing.T) {
+	doc := js.Global().Get("document")
+	script := doc.Call("createElement", "script")
+	script.Set("type", "application/json")
+	script.Set("id", statePayloadID)
+	script.Set("textContent", `{"filter":"open"}`)
+	doc.Get("body").Call("appendChild", script)
+	transferLoaded, transferState = false, js.Undefined()
+	defer func() {
+		script.Call("remove")
+		transferLoaded, transferState = false, js.Undefined()
+	}()
+
+	if got := TransferString(nil, "filter", NewString("all")).Get(); got != "open" {
+		t.Fatalf("first Init: want the server's value, got %q", got)
+	}
+	if got := TransferString(nil, "filter", NewString("all")).Get(); got != "all" {
+		t.Errorf("a remount must not reapply the stale snapshot, got %q", got)
+	}
+}
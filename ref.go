@@ -0,0 +1,32 @@
+package dom
+
+// Ref holds the live Reference of an element built in Render, filled by the
+// engine through Element.Ref. Declare it as a component field:
+//
+//	type Search struct {
+//		dom.Element
+//		input dom.Ref
+//	}
+//
+//	func (s *Search) Render() *dom.Element {
+//		return html.Input("search").Ref(&s.input)
+//	}
+//
+//	func (s *Search) Mounted() {
+//		if in, ok := s.input.Get(); ok {
+//			in.Focus()
+//		}
+//	}
+type Ref struct {
+	ref Reference
+}
+
+// Get returns the element's Reference while it is in the page. It reports
+// false before the first mount, after the element is removed, and always on
+// the backend.
+func (r *Ref) Get() (Reference, bool) {
+	if r == nil || r.ref == nil {
+		return nil, false
+	}
+	return r.ref, true
+}
@@ -1,5 +1,7 @@
 package dom
 
+import "github.com/tinywasm/fmt"
+
 // Reference represents a reference to a DOM node. It provides methods for reading and interaction.
 type Reference interface {
 	// --- Attributes ---
@@ -36,6 +38,21 @@ type Reference interface {
 	// On registers a generic event handler (e.g., "click", "change", "input", "keydown").
 	On(eventType string, handler func(event Event))
 
+	// OnWith registers an event handler with listener options: passive touch
+	// and wheel listeners, capture-phase handlers, one-shot listeners. It
+	// returns a function that removes this listener before its component
+	// unmounts; calling it more than once is harmless.
+	OnWith(eventType string, opts ListenerOptions, handler func(event Event)) (remove func())
+
+	// Dispatch fires a custom event named name on the element, with detail as
+	// its payload (Event.Detail). It bubbles, crosses shadow boundaries and is
+	// heard by any ancestor's On(name, ...) — the way a row tells its table it
+	// was selected without knowing the table's id.
+	Dispatch(name, detail string)
+
+	// DispatchKV is Dispatch with a key/value payload (Event.DetailValue).
+	DispatchKV(name string, detail ...fmt.KeyValue)
+
 	// Focus sets focus to the element.
 	Focus()
 
@@ -54,4 +71,65 @@ type Reference interface {
 	// has to know which it is looking at: on the wide layout the nearest
 	// scroller is somebody else's, and scrolling it moves the whole application.
 	ScrollsX() bool
+
+	// --- Geometry ---
+
+	// BoundingRect returns the element's border box relative to the viewport
+	// (getBoundingClientRect) — what a tooltip or a drag ghost positions
+	// against.
+	BoundingRect() Rect
+
+	// ScrollTop and ScrollLeft return how far the element's content is
+	// scrolled; SetScrollTop and SetScrollLeft move it instantly.
+	ScrollTop() float64
+	ScrollLeft() float64
+	SetScrollTop(v float64)
+	SetScrollLeft(v float64)
+
+	// ScrollHeight and ScrollWidth return the size of the scrolled content,
+	// which with BoundingRect gives a virtual list its window.
+	ScrollHeight() float64
+	ScrollWidth() float64
+
+	// --- Classes and styles ---
+
+	// AddClass, RemoveClass and HasClass operate on classList.
+	AddClass(name string)
+	RemoveClass(name string)
+	HasClass(name string) bool
+
+	// ToggleClass flips name and reports whether it is now present.
+	ToggleClass(name string) bool
+
+	// ComputedStyle returns the resolved value of a CSS property
+	// ("font-size" → "16px"), after stylesheets and inheritance.
+	ComputedStyle(property string) string
+
+	// --- Navigation ---
+
+	// Query returns the first descendant matching a CSS selector. A selector
+	// the browser rejects finds nothing.
+	Query(selector string) (Reference, bool)
+
+	// QueryAll returns every descendant matching a CSS selector; none for a
+	// selector the browser rejects.
+	QueryAll(selector string) []Reference
+
+	// Parent returns the parent element; false at the top of the document or
+	// of a shadow root.
+	Parent() (Reference, bool)
+
+	// Children returns the element children, text nodes excluded.
+	Children() []Reference
+}
+
+// Rect is an element box in CSS pixels, relative to the viewport.
+type Rect struct {
+	X, Y, Width, Height float64
 }
+
+// Right returns X + Width.
+func (r Rect) Right() float64 { return r.X + r.Width }
+
+// Bottom returns Y + Height.
+func (r Rect) Bottom() float64 { return r.Y + r.Height }
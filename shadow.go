@@ -0,0 +1,23 @@
+package dom
+
+// ShadowOptions configures AttachShadow.
+type ShadowOptions struct {
+	// Closed makes a closed shadow root: page scripts cannot reach inside
+	// through host.shadowRoot. The root that attached it still can.
+	Closed bool
+	// Styles are CSS texts applied inside the root only. Host page CSS does
+	// not cross the boundary, and these do not leak out.
+	Styles []string
+}
+
+// AttachShadow attaches a shadow root to the element hostID and returns the id
+// of a mount point inside it, to pass to Render or Append. Widgets embedded in
+// third-party pages render there, out of reach of the host's CSS:
+//
+//	mount, err := dom.AttachShadow("widget-host", dom.ShadowOptions{Styles: []string{css}})
+//	dom.Render(mount, &Widget{})
+//
+// Get, event handlers and bindings resolve inside the root like anywhere else.
+func AttachShadow(hostID string, opts ShadowOptions) (mountID string, err error) {
+	return instance.AttachShadow(hostID, opts)
+}
@@ -0,0 +1,129 @@
+//go:build wasm
+
+package dom
+
+import (
+	"syscall/js"
+
+	"github.com/tinywasm/fmt"
+)
+
+// AttachShadow attaches a shadow root to hostID and creates the mount point
+// inside it. The mount is a <div> rather than the root itself so Render's
+// innerHTML never wipes the <style> fallback that sits next to it.
+func (d *domWasm) AttachShadow(hostID string, opts ShadowOptions) (string, error) {
+	host := d.getElement(hostID)
+	if host.IsNull() || host.IsUndefined() {
+		return "", fmt.Errf("shadow host not found: %s", hostID)
+	}
+	if tag := host.Get("localName").String(); !canHostShadow(tag) {
+		return "", fmt.Errf("<%s> cannot host a shadow root: %s", tag, hostID)
+	}
+	if host.Get("shadowRoot").Truthy() || hasOwnShadow(host) {
+		return "", fmt.Errf("shadow host already has a shadow root: %s", hostID)
+	}
+	init := js.Global().Get("Object").New()
+	mode := "open"
+	if opts.Closed {
+		mode = "closed"
+	}
+	init.Set("mode", mode)
+	sr, err := attachShadow(host, init)
+	if err != nil {
+		return "", err
+	}
+	d.shadows = append(d.shadows, sr)
+	// Attached from a component's Init, the root is forgotten when that
+	// component unmounts; otherwise it lives with the DOM root.
+	if owner := d.currentComponentID; owner != "" {
+		d.cleanups = append(d.cleanups, struct {
+			id string
+			fn func()
+		}{owner, func() { d.dropShadow(sr) }})
+	}
+
+	adoptStyles(d.document, sr, opts.Styles)
+
+	mountID := generateID()
+	mount := d.document.Call("createElement", "div")
+	mount.Set("id", mountID)
+	sr.Call("appendChild", mount)
+	return mountID, nil
+}
+
+// dropShadow forgets a shadow root, so lookups stop searching it.
+func (d *domWasm) dropShadow(sr js.Value) {
+	for i, own := range d.shadows {
+		if own.Equal(sr) {
+			d.shadows = append(d.shadows[:i], d.shadows[i+1:]...)
+			return
+		}
+	}
+}
+
+// shadowHosts are the elements the spec allows attachShadow on, besides
+// custom elements.
+var shadowHosts = []string{
+	"article", "aside", "blockquote", "body", "div", "footer",
+	"h1", "h2", "h3", "h4", "h5", "h6", "header", "main", "nav", "p",
+	"section", "span",
+}
+
+// canHostShadow reports whether attachShadow accepts an element of tag.
+func canHostShadow(tag string) bool {
+	return validCustomElementName(tag) || containsString(shadowHosts, tag)
+}
+
+// hasOwnShadow reports whether a root on this page already attached a shadow
+// root to host: a closed one does not show through host.shadowRoot.
+func hasOwnShadow(host js.Value) bool {
+	for _, d := range roots {
+		for _, sr := range d.shadows {
+			if sr.Get("host").Equal(host) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// attachShadow calls host.attachShadow, turning the exception the browser
+// throws for a host it refuses (a closed root a third-party script attached,
+// say) into an error.
+func attachShadow(host, init js.Value) (sr js.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			jsErr, ok := r.(js.Error)
+			if !ok {
+				panic(r)
+			}
+			err = fmt.Errf("attachShadow failed: %s", jsErr.Error())
+		}
+	}()
+	return host.Call("attachShadow", init), nil
+}
+
+// adoptStyles applies styles as constructed stylesheets, shared by reference
+// and never re-parsed. Browsers without them get <style> elements, made by
+// doc, the root's document.
+func adoptStyles(doc, sr js.Value, styles []string) {
+	if len(styles) == 0 {
+		return
+	}
+	ctor := js.Global().Get("CSSStyleSheet")
+	if ctor.Truthy() && sr.Get("adoptedStyleSheets").Truthy() {
+		sheets := js.Global().Get("Array").New()
+		for _, css := range styles {
+			sheet := ctor.New()
+			sheet.Call("replaceSync", css)
+			sheets.Call("push", sheet)
+		}
+		sr.Set("adoptedStyleSheets", sheets)
+		return
+	}
+	for _, css := range styles {
+		style := doc.Call("createElement", "style")
+		style.Set("textContent", css)
+		sr.Call("appendChild", style)
+	}
+}
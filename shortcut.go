@@ -0,0 +1,155 @@
+package dom
+
+import "github.com/tinywasm/fmt"
+
+// chord is a parsed shortcut: the key (lower case, aliases resolved) and the
+// modifiers that must be held. mod is the platform primary modifier, resolved
+// against Meta or Ctrl when the event arrives.
+type chord struct {
+	key                         string
+	mod, ctrl, alt, shift, meta bool
+}
+
+// keyAliases maps the names a chord may use to the event's lower-cased Key.
+var keyAliases = []fmt.KeyValue{
+	{Key: "esc", Value: "escape"},
+	{Key: "space", Value: " "},
+	{Key: "plus", Value: "+"},
+	{Key: "up", Value: "arrowup"},
+	{Key: "down", Value: "arrowdown"},
+	{Key: "left", Value: "arrowleft"},
+	{Key: "right", Value: "arrowright"},
+	{Key: "del", Value: "delete"},
+	{Key: "return", Value: "enter"},
+}
+
+// parseChord reads "Mod+Shift+K", "Esc", "j", "Ctrl+Plus". Names are case
+// insensitive; Mod is Cmd on Apple platforms and Ctrl elsewhere.
+func parseChord(s string) (chord, error) {
+	var c chord
+	parts := fmt.Split(fmt.ToLower(s), "+")
+	for i, p := range parts {
+		p = fmt.TrimSpace(p)
+		if i == len(parts)-1 {
+			if p == "" {
+				return c, fmt.Err("shortcut", s, "has no key")
+			}
+			c.key = p
+			if v, ok := attrValue(keyAliases, p); ok {
+				c.key = v
+			}
+			return c, nil
+		}
+		switch p {
+		case "mod":
+			c.mod = true
+		case "ctrl", "control":
+			c.ctrl = true
+		case "alt", "option":
+			c.alt = true
+		case "shift":
+			c.shift = true
+		case "meta", "cmd", "command":
+			c.meta = true
+		default:
+			return c, fmt.Err("shortcut", s, "unknown modifier", p)
+		}
+	}
+	return c, fmt.Err("shortcut", s, "is empty")
+}
+
+// matches reports whether e is the chord. isMac resolves Mod. Shift is not
+// compared for single symbols ("?", "!"), which need it on most layouts to be
+// typed at all.
+func (c chord) matches(e Event, isMac bool) bool {
+	if !c.keyMatches(e) {
+		return false
+	}
+	ctrl, meta := c.ctrl, c.meta
+	if c.mod {
+		if isMac {
+			meta = true
+		} else {
+			ctrl = true
+		}
+	}
+	if e.CtrlKey() != ctrl || e.MetaKey() != meta || e.AltKey() != c.alt {
+		return false
+	}
+	if len(c.key) == 1 && !isAlnum(c.key[0]) {
+		return true
+	}
+	return e.ShiftKey() == c.shift
+}
+
+// keyMatches compares the chord's key with the event's. A letter or digit
+// chord falls back to the physical key when Key is not that letter or digit:
+// Alt+K types "˚" on macOS and Shift+1 types "!", but Code still says "KeyK"
+// and "Digit1". Key comes first so a layout that moves letters (AZERTY, Dvorak)
+// still matches the letter printed on the key.
+func (c chord) keyMatches(e Event) bool {
+	key := fmt.ToLower(e.Key())
+	if key == c.key {
+		return true
+	}
+	if len(c.key) != 1 || !isAlnum(c.key[0]) || len(key) == 1 && isAlnum(key[0]) {
+		return false
+	}
+	code := e.Code()
+	if c.key[0] >= '0' && c.key[0] <= '9' {
+		return code == "Digit"+c.key || code == "Numpad"+c.key
+	}
+	return code == "Key"+fmt.ToUpper(c.key)
+}
+
+// firesWhileTyping reports whether the chord still applies with focus in a
+// text field: only with a command modifier (Ctrl+S saves from inside the
+// editor) or for Escape (closes the dialog the field is in). A bare "j" or
+// Shift+K is text.
+func (c chord) firesWhileTyping() bool {
+	return c.mod || c.ctrl || c.meta || c.alt || c.key == "escape"
+}
+
+func isAlnum(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9'
+}
+
+// Shortcut registers a keyboard shortcut scoped to the component that owns
+// ctx (the Ctx handed to its Init): it fires only while focus is inside that
+// component's root element. Use it for per-panel keys — j/k in a list, Esc in
+// a dialog. It is removed when the component unmounts.
+//
+// When several shortcuts match one key press only one runs: the one whose
+// scope is innermost around the focus, then the global one registered last.
+// Two panels both handling Esc therefore do not both close. The winner's
+// event has PreventDefault called, so Mod+S does not open the browser's save
+// dialog.
+//
+// While focus is in a text field (input, textarea, select, contenteditable)
+// only chords with Ctrl, Meta, Alt or Mod, and Esc, fire: typing "j" into a
+// search box never moves the list.
+//
+// ctx must be the Ctx of a mounted component's Init; without one there is no
+// scope, and Shortcut returns an error rather than quietly registering a
+// page-wide shortcut nothing removes. Page-wide keys are GlobalShortcut's.
+func Shortcut(ctx Ctx, keys string, handler func(Event)) error {
+	c, err := parseChord(keys)
+	if err != nil {
+		return err
+	}
+	if ctx == nil {
+		return fmt.Err("shortcut", keys, "needs the Ctx of the component it is scoped to; use GlobalShortcut for page-wide keys")
+	}
+	return registerShortcut(ctx, c, true, handler)
+}
+
+// GlobalShortcut registers a keyboard shortcut active anywhere on the page
+// while the component that owns ctx is mounted (for as long as the page lives
+// with a nil ctx). Use it for app-wide keys such as Mod+K.
+func GlobalShortcut(ctx Ctx, keys string, handler func(Event)) error {
+	c, err := parseChord(keys)
+	if err != nil {
+		return err
+	}
+	return registerShortcut(ctx, c, false, handler)
+}
@@ -0,0 +1,9 @@
+//go:build !wasm
+
+package dom
+
+// registerShortcut is a no-op on the backend: there is no keyboard under SSR.
+// The chord is still parsed by the caller, so a typo fails in server tests too.
+func registerShortcut(ctx Ctx, c chord, scoped bool, handler func(Event)) error {
+	return nil
+}
@@ -0,0 +1,86 @@
+package dom
+
+import "testing"
+
+func TestParseChord(t *testing.T) {
+	c, err := parseChord("Mod+Shift+K")
+	if err != nil || c.key != "k" || !c.mod || !c.shift || c.ctrl {
+		t.Errorf("Mod+Shift+K: %+v, %v", c, err)
+	}
+	if c, _ := parseChord("Esc"); c.key != "escape" {
+		t.Errorf("Esc alias: %q", c.key)
+	}
+	if c, _ := parseChord("Ctrl+Plus"); c.key != "+" || !c.ctrl {
+		t.Errorf("Ctrl+Plus: %+v", c)
+	}
+	for _, bad := range []string{"", "Ctrl+", "Hyper+K"} {
+		if _, err := parseChord(bad); err == nil {
+			t.Errorf("%q should not parse", bad)
+		}
+	}
+}
+
+func TestChordMatches(t *testing.T) {
+	save, _ := parseChord("Mod+S")
+	ctrlS := &SyntheticEvent{KeyName: "s", Ctrl: true}
+	cmdS := &SyntheticEvent{KeyName: "s", Meta: true}
+	if !save.matches(ctrlS, false) || save.matches(cmdS, false) {
+		t.Error("Mod must be Ctrl off Apple platforms")
+	}
+	if !save.matches(cmdS, true) || save.matches(ctrlS, true) {
+		t.Error("Mod must be Cmd on Apple platforms")
+	}
+
+	k, _ := parseChord("k")
+	if !k.matches(&SyntheticEvent{KeyName: "k"}, false) || k.matches(&SyntheticEvent{KeyName: "K", Shift: true}, false) {
+		t.Error("a letter chord must tell k from Shift+K")
+	}
+	altK, _ := parseChord("Alt+K")
+	if !altK.matches(&SyntheticEvent{KeyName: "˚", CodeName: "KeyK", Alt: true}, true) {
+		t.Error("Alt+K must match on macOS, where the key arrives as ˚")
+	}
+	shift1, _ := parseChord("Shift+1")
+	bang := &SyntheticEvent{KeyName: "!", CodeName: "Digit1", Shift: true}
+	if !shift1.matches(bang, false) {
+		t.Error("Shift+1 must match although the key arrives as !")
+	}
+	one, _ := parseChord("1")
+	if one.matches(bang, false) {
+		t.Error("1 must not match Shift+1")
+	}
+	z, _ := parseChord("Mod+Z")
+	if z.matches(&SyntheticEvent{KeyName: "w", CodeName: "KeyZ", Ctrl: true}, false) {
+		t.Error("a letter on the key wins over its physical position")
+	}
+	help, _ := parseChord("?")
+	if !help.matches(&SyntheticEvent{KeyName: "?", Shift: true}, false) {
+		t.Error("? must match although Shift is needed to type it")
+	}
+}
+
+func TestChordWhileTyping(t *testing.T) {
+	for _, tc := range []struct {
+		keys string
+		want bool
+	}{{"j", false}, {"Shift+K", false}, {"Mod+S", true}, {"Alt+1", true}, {"Esc", true}} {
+		c, _ := parseChord(tc.keys)
+		if got := c.firesWhileTyping(); got != tc.want {
+			t.Errorf("%s firesWhileTyping = %v, want %v", tc.keys, got, tc.want)
+		}
+	}
+}
+
+func TestShortcutRejectsBadChord(t *testing.T) {
+	if err := GlobalShortcut(nil, "Hyper+K", func(Event) {}); err == nil {
+		t.Error("a bad chord must be reported, also on the backend")
+	}
+	if err := GlobalShortcut(nil, "Mod+K", func(Event) {}); err != nil {
+		t.Errorf("valid chord: %v", err)
+	}
+}
+
+func TestShortcutNeedsScope(t *testing.T) {
+	if err := Shortcut(nil, "Esc", func(Event) {}); err == nil {
+		t.Error("a scoped shortcut without a Ctx must be an error, not a page-wide shortcut")
+	}
+}
@@ -0,0 +1,169 @@
+//go:build wasm
+
+package dom
+
+import (
+	"syscall/js"
+
+	"github.com/tinywasm/fmt"
+)
+
+// The keyboard is one per page, so the registry is package-level, like the
+// head's: shortcuts in registration order and the single keydown listener on
+// the document that serves them all.
+var (
+	shortcuts []*shortcut
+	keydownFn js.Func
+	isMac     bool
+)
+
+type shortcut struct {
+	chord   chord
+	scope   string   // owner component id; "" for global
+	dom     *domWasm // the root the owner lives in, which resolves scope
+	handler func(Event)
+}
+
+func registerShortcut(ctx Ctx, c chord, scoped bool, handler func(Event)) error {
+	dc, ok := ctx.(*domCtx)
+	if scoped && !ok {
+		return fmt.Errf("shortcut: a scoped shortcut needs the Ctx handed to a component's Init")
+	}
+	d := current()
+	if keydownFn.IsUndefined() {
+		isMac = applePlatform()
+		keydownFn = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			onShortcutKey(&eventWasm{Value: args[0]})
+			return nil
+		})
+		d.document.Call("addEventListener", "keydown", keydownFn)
+	}
+
+	s := &shortcut{chord: c, dom: d, handler: handler}
+	if scoped {
+		s.scope, s.dom = dc.id, dc.d
+	}
+	shortcuts = append(shortcuts, s)
+	if ctx != nil {
+		ctx.OnCleanup(func() {
+			for i, item := range shortcuts {
+				if item == s {
+					shortcuts = append(shortcuts[:i], shortcuts[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+	return nil
+}
+
+// applePlatform reports whether Mod means Cmd: from
+// navigator.userAgentData.platform where the browser has it, else from the
+// deprecated navigator.platform.
+func applePlatform() bool {
+	nav := js.Global().Get("navigator")
+	platform := ""
+	if data := nav.Get("userAgentData"); data.Truthy() {
+		platform = data.Get("platform").String()
+	}
+	if platform == "" {
+		if p := nav.Get("platform"); p.Type() == js.TypeString {
+			platform = p.String()
+		}
+	}
+	platform = fmt.ToLower(platform)
+	for _, p := range []string{"mac", "iphone", "ipad", "ipod", "ios"} {
+		if fmt.HasPrefix(platform, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// onShortcutKey picks the one shortcut a key press belongs to.
+func onShortcutKey(e *eventWasm) {
+	if e.Get("defaultPrevented").Bool() || e.Get("isComposing").Bool() {
+		return
+	}
+	var best *shortcut
+	bestDepth := -1
+	for _, s := range shortcuts {
+		if !s.chord.matches(e, isMac) {
+			continue
+		}
+		// Asked per root: only the root that attached a closed shadow root
+		// can see the focus inside it.
+		focus := s.dom.activeElement()
+		if isTextField(focus) && !s.chord.firesWhileTyping() {
+			continue
+		}
+		depth := 0
+		if s.scope != "" {
+			depth = scopeDepth(s.dom.getElement(s.scope), focus)
+			if depth == 0 {
+				continue
+			}
+		}
+		// >= : later registrations win ties (the dialog opened last).
+		if depth >= bestDepth {
+			best, bestDepth = s, depth
+		}
+	}
+	if best != nil {
+		// The listener is shared by every root; the event belongs to the one
+		// that registered the winner.
+		e.dom = best.dom
+		e.PreventDefault()
+		best.handler(e)
+	}
+}
+
+// scopeDepth returns how deep scope sits in the page (1 for a child of the
+// root) when node is inside it, 0 when it is not: the innermost scope around
+// the focus is the deepest one.
+func scopeDepth(scope, node js.Value) int {
+	if !scope.Truthy() {
+		return 0
+	}
+	inside := false
+	for n := node; n.Truthy(); n = parentAcrossShadow(n) {
+		if n.Equal(scope) {
+			inside = true
+			break
+		}
+	}
+	if !inside {
+		return 0
+	}
+	depth := 1
+	for n := parentAcrossShadow(scope); n.Truthy(); n = parentAcrossShadow(n) {
+		depth++
+	}
+	return depth
+}
+
+// parentAcrossShadow steps to the parent node, from a shadow root to its host.
+func parentAcrossShadow(n js.Value) js.Value {
+	if n.Get("nodeType").Int() == 11 && n.Get("host").Truthy() {
+		return n.Get("host")
+	}
+	return n.Get("parentNode")
+}
+
+// isTextField reports whether node takes typed text.
+func isTextField(node js.Value) bool {
+	if !node.Truthy() {
+		return false
+	}
+	switch node.Get("tagName").String() {
+	case "TEXTAREA", "SELECT":
+		return true
+	case "INPUT":
+		switch node.Get("type").String() {
+		case "checkbox", "radio", "button", "submit", "reset", "range", "color", "file":
+			return false
+		}
+		return true
+	}
+	return node.Get("isContentEditable").Bool()
+}
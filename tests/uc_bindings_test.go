@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	. "github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
 )
 
 // setupBindRoot prepares a clean #bind-root div in the page body.
@@ -142,3 +143,301 @@ func TestBindText_ChildComponent_UpdatesDOM(t *testing.T) {
 		t.Errorf("after Set: want 'child-updated', got %q — child component bindings not wired (mountRecursive missing wireBindings)", got)
 	}
 }
+
+// PropComp — BindPropBool drives a property with no attribute form
+// (indeterminate), so the first value must be written at wire time.
+type PropComp struct {
+	Element
+	mixed *SignalBool
+}
+
+func (c *PropComp) Init(_ Ctx) { c.mixed = NewBool(true) }
+func (c *PropComp) Render() *Element {
+	return NewElement("input").ID("prop-cbx").Attr("type", "checkbox").
+		BindPropBool("indeterminate", c.mixed)
+}
+
+func TestBindPropBool_SetsLiveProperty(t *testing.T) {
+	setupBindRoot()
+	comp := &PropComp{}
+	comp.SetID("prop-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	cbx := js.Global().Get("document").Call("getElementById", "prop-cbx")
+	if !cbx.Get("indeterminate").Bool() {
+		t.Fatal("initial: .indeterminate want true")
+	}
+	comp.mixed.Set(false)
+	if cbx.Get("indeterminate").Bool() {
+		t.Error("after Set(false): .indeterminate still true")
+	}
+}
+
+// ClassNameComp — BindClassName swaps a variant set and must leave the static
+// Class untouched even when the bound value also listed it.
+type ClassNameComp struct {
+	Element
+	variant *SignalString
+}
+
+func (c *ClassNameComp) Init(_ Ctx) { c.variant = NewString("btn size-sm") }
+func (c *ClassNameComp) Render() *Element {
+	return NewElement("button").ID("cn-btn").Class("btn").BindClassName(c.variant)
+}
+
+func TestBindClassName_DiffsAgainstPreviousSet(t *testing.T) {
+	setupBindRoot()
+	comp := &ClassNameComp{}
+	comp.SetID("cn-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	btn := js.Global().Get("document").Call("getElementById", "cn-btn")
+
+	comp.variant.Set("size-lg theme-dark")
+	if got := btn.Get("className").String(); got != "btn size-lg theme-dark" {
+		t.Errorf("after Set: className want %q, got %q", "btn size-lg theme-dark", got)
+	}
+
+	comp.variant.Set("")
+	if got := btn.Get("className").String(); got != "btn" {
+		t.Errorf("after clearing: className want %q, got %q", "btn", got)
+	}
+}
+
+// RadioComp — BindRadio keeps every radio of a group in sync with one signal,
+// in both directions.
+type RadioComp struct {
+	Element
+	size *SignalString
+}
+
+func (c *RadioComp) Init(_ Ctx) { c.size = NewString("s") }
+func (c *RadioComp) Render() *Element {
+	radio := func(v string) *Element {
+		return NewElement("input").ID("radio-"+v).Attr("type", "radio").Attr("name", "size").
+			Attr("value", v).BindRadio(c.size)
+	}
+	return NewElement("div").ID(c.GetID()).Child(radio("s"), radio("m"))
+}
+
+func TestBindRadio_TwoWay(t *testing.T) {
+	setupBindRoot()
+	comp := &RadioComp{}
+	comp.SetID("radio-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	doc := js.Global().Get("document")
+	s, m := doc.Call("getElementById", "radio-s"), doc.Call("getElementById", "radio-m")
+	if !s.Get("checked").Bool() || m.Get("checked").Bool() {
+		t.Fatal("initial: want s checked, m not")
+	}
+
+	comp.size.Set("m")
+	if s.Get("checked").Bool() || !m.Get("checked").Bool() {
+		t.Error("after Set(m): want m checked, s not")
+	}
+
+	s.Call("click") // user picks s: "change" fires on s
+	if got := comp.size.Get(); got != "s" {
+		t.Errorf("after click: signal want %q, got %q", "s", got)
+	}
+}
+
+// SearchComp — BindForceWrite: clearing the signal after submit must show in
+// the field even though it still has focus (plain Bind skips the focused field).
+type SearchComp struct {
+	Element
+	q *SignalString
+}
+
+func (c *SearchComp) Init(_ Ctx) { c.q = NewString("") }
+func (c *SearchComp) Render() *Element {
+	return NewElement("input").ID("search-q").Attr("type", "text").BindValue(c.q, BindForceWrite)
+}
+
+func TestBindValue_ForceWriteUpdatesFocusedField(t *testing.T) {
+	setupBindRoot()
+	comp := &SearchComp{}
+	comp.SetID("search-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	input := js.Global().Get("document").Call("getElementById", "search-q")
+	input.Call("focus")
+	input.Set("value", "shoes")
+	input.Call("dispatchEvent", js.Global().Get("Event").New("input"))
+	if got := comp.q.Get(); got != "shoes" {
+		t.Fatalf("after input: signal want %q, got %q", "shoes", got)
+	}
+
+	comp.q.Set("")
+	if got := input.Get("value").String(); got != "" {
+		t.Errorf("after Set(\"\") while focused: field want empty, got %q", got)
+	}
+}
+
+func TestBindValue_ForceWriteCommitsComposedText(t *testing.T) {
+	setupBindRoot()
+	comp := &SearchComp{}
+	comp.SetID("search-ime-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	input := js.Global().Get("document").Call("getElementById", "search-q")
+	input.Call("focus")
+
+	// Chrome and Safari order: the last input fires before compositionend.
+	input.Call("dispatchEvent", js.Global().Get("CompositionEvent").New("compositionstart"))
+	input.Set("value", "日本")
+	input.Call("dispatchEvent", js.Global().Get("Event").New("input"))
+	input.Call("dispatchEvent", js.Global().Get("CompositionEvent").New("compositionend"))
+
+	if got := comp.q.Get(); got != "日本" {
+		t.Errorf("ForceWrite alone must not swallow the composed text, signal got %q", got)
+	}
+}
+
+// AmountComp — BindWith parses user input back into the canonical value and
+// reports parse failures through the error signal instead of writing garbage.
+type AmountComp struct {
+	Element
+	amount, err *SignalString
+}
+
+func (c *AmountComp) Init(_ Ctx) { c.amount, c.err = NewString("5"), NewString("") }
+func (c *AmountComp) Render() *Element {
+	format := func(v string) string { return v + " €" }
+	parse := func(v string) (string, error) {
+		v = fmt.TrimSpace(fmt.TrimSuffix(v, "€"))
+		if _, err := fmt.Convert(v).Float64(); err != nil {
+			return "", fmt.Err("not a number")
+		}
+		return v, nil
+	}
+	return NewElement("input").ID("amount").Attr("type", "text").BindWith(c.amount, format, parse, c.err)
+}
+
+func TestBindWith_ParsesAndReportsErrors(t *testing.T) {
+	setupBindRoot()
+	comp := &AmountComp{}
+	comp.SetID("amount-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	input := js.Global().Get("document").Call("getElementById", "amount")
+	if got := input.Get("value").String(); got != "5 €" {
+		t.Fatalf("initial: field want %q, got %q", "5 €", got)
+	}
+	typeInto := func(v string) {
+		input.Set("value", v)
+		input.Call("dispatchEvent", js.Global().Get("Event").New("input"))
+	}
+
+	typeInto("abc")
+	if comp.amount.Get() != "5" || comp.err.Get() != "not a number" {
+		t.Errorf("bad input: amount=%q err=%q", comp.amount.Get(), comp.err.Get())
+	}
+
+	typeInto("7.5")
+	if comp.amount.Get() != "7.5" || comp.err.Get() != "" {
+		t.Errorf("good input: amount=%q err=%q", comp.amount.Get(), comp.err.Get())
+	}
+}
+
+// NoteComp — BindEditable on a contenteditable div.
+type NoteComp struct {
+	Element
+	text *SignalString
+}
+
+func (c *NoteComp) Init(_ Ctx) { c.text = NewString("hello world") }
+func (c *NoteComp) Render() *Element {
+	return NewElement("div").ID("note").BindEditable(c.text)
+}
+
+func renderNote(t *testing.T) (*NoteComp, js.Value) {
+	setupBindRoot()
+	comp := &NoteComp{}
+	comp.SetID("note-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	return comp, js.Global().Get("document").Call("getElementById", "note")
+}
+
+func TestBindEditable_KeepsTheCaretAcrossUpdates(t *testing.T) {
+	comp, note := renderNote(t)
+	note.Call("focus")
+	r := js.Global().Get("document").Call("createRange")
+	r.Call("setStart", note.Get("firstChild"), 5)
+	r.Call("collapse", true)
+	sel := js.Global().Call("getSelection")
+	sel.Call("removeAllRanges")
+	sel.Call("addRange", r)
+
+	comp.text.Set("hello brave world")
+	if got := note.Get("innerText").String(); got != "hello brave world" {
+		t.Fatalf("element want %q, got %q", "hello brave world", got)
+	}
+	if got := sel.Get("anchorOffset").Int(); got != 5 {
+		t.Errorf("caret should stay at offset 5, got %d", got)
+	}
+}
+
+func TestBindEditable_KeepsASelectedRangeAcrossUpdates(t *testing.T) {
+	comp, note := renderNote(t)
+	note.Call("focus")
+	r := js.Global().Get("document").Call("createRange")
+	r.Call("setStart", note.Get("firstChild"), 6)
+	r.Call("setEnd", note.Get("firstChild"), 11) // "world"
+	sel := js.Global().Call("getSelection")
+	sel.Call("removeAllRanges")
+	sel.Call("addRange", r)
+
+	comp.text.Set("hello there")
+	if got := sel.Call("toString").String(); got != "there" {
+		t.Errorf("the selection should still cover offsets 6-11, selected %q", got)
+	}
+	if sel.Get("isCollapsed").Bool() {
+		t.Error("a selected range must not collapse on a programmatic update")
+	}
+}
+
+func TestBindEditable_HoldsUpdatesDuringComposition(t *testing.T) {
+	comp, note := renderNote(t)
+	dispatch := func(typ string) {
+		note.Call("dispatchEvent", js.Global().Get("CompositionEvent").New(typ, map[string]any{"bubbles": true}))
+	}
+
+	dispatch("compositionstart")
+	note.Set("innerText", "hello wo")
+	comp.text.Set("from server")
+	if got := note.Get("innerText").String(); got != "hello wo" {
+		t.Errorf("mid-composition the element must not be touched, got %q", got)
+	}
+
+	dispatch("compositionend")
+	if got := note.Get("innerText").String(); got != "from server" {
+		t.Errorf("the held update should apply at compositionend, element got %q", got)
+	}
+	if got := comp.text.Get(); got != "from server" {
+		t.Errorf("compositionend must not overwrite the held update, signal got %q", got)
+	}
+}
+
+func TestBindEditable_CommitsCompositionText(t *testing.T) {
+	comp, note := renderNote(t)
+	note.Call("dispatchEvent", js.Global().Get("CompositionEvent").New("compositionstart"))
+	note.Set("innerText", "hello 世界")
+	note.Call("dispatchEvent", js.Global().Get("Event").New("input"))
+	if got := comp.text.Get(); got != "hello world" {
+		t.Errorf("input during composition must not write back, got %q", got)
+	}
+	note.Call("dispatchEvent", js.Global().Get("CompositionEvent").New("compositionend"))
+	if got := comp.text.Get(); got != "hello 世界" {
+		t.Errorf("compositionend should commit the text, got %q", got)
+	}
+}
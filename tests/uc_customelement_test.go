@@ -0,0 +1,111 @@
+//go:build wasm
+
+package dom_test
+
+import (
+	"syscall/js"
+	"testing"
+
+	. "github.com/tinywasm/dom"
+)
+
+type TagCounter struct {
+	Element
+	start   *SignalString
+	cleaned *int
+}
+
+func (c *TagCounter) Init(ctx Ctx)     { ctx.OnCleanup(func() { *c.cleaned++ }) }
+func (c *TagCounter) Render() *Element { return NewElement("span").BindText(c.start) }
+
+// TestDefineElement_PlainHTMLMountsTheComponent — <x-counter start="3"> in
+// markup mounts a Go component, follows setAttribute, and cleans up on removal.
+func TestDefineElement_PlainHTMLMountsTheComponent(t *testing.T) {
+	cleaned := 0
+	err := DefineElement("x-counter", func(a *HostAttrs) Component {
+		return &TagCounter{start: a.Attr("start"), cleaned: &cleaned}
+	}, "start")
+	if err != nil {
+		t.Fatalf("DefineElement: %v", err)
+	}
+
+	doc := js.Global().Get("document")
+	box := doc.Call("createElement", "div")
+	box.Set("innerHTML", "<x-counter start='3'></x-counter>")
+	doc.Get("body").Call("appendChild", box) // connects the tag
+	tag := box.Call("querySelector", "x-counter")
+
+	if got := tag.Get("textContent").String(); got != "3" {
+		t.Fatalf("mounted text %q, want 3", got)
+	}
+	tag.Call("setAttribute", "start", "7")
+	if got := tag.Get("textContent").String(); got != "7" {
+		t.Errorf("after setAttribute: %q, want 7", got)
+	}
+
+	box.Call("remove")
+	if cleaned != 1 {
+		t.Errorf("removing the tag ran %d cleanups, want 1", cleaned)
+	}
+	if err := DefineElement("x-counter", nil); err == nil {
+		t.Error("defining the same tag twice must fail")
+	}
+}
+
+// TestDefineElement_UnobservedAttrKeepsItsMountValue — an attribute that is
+// not observed still reads its value at mount; it just does not follow later.
+func TestDefineElement_UnobservedAttrKeepsItsMountValue(t *testing.T) {
+	cleaned := 0
+	err := DefineElement("x-labelled", func(a *HostAttrs) Component {
+		return &TagCounter{start: a.Attr("title"), cleaned: &cleaned}
+	})
+	if err != nil {
+		t.Fatalf("DefineElement: %v", err)
+	}
+
+	doc := js.Global().Get("document")
+	box := doc.Call("createElement", "div")
+	box.Set("innerHTML", "<x-labelled title='hello'></x-labelled>")
+	doc.Get("body").Call("appendChild", box)
+	defer box.Call("remove")
+	tag := box.Call("querySelector", "x-labelled")
+
+	if got := tag.Get("textContent").String(); got != "hello" {
+		t.Fatalf("unobserved attribute at mount: %q, want hello", got)
+	}
+	tag.Call("setAttribute", "title", "later")
+	if got := tag.Get("textContent").String(); got != "hello" {
+		t.Errorf("an unobserved attribute must keep its mount value, got %q", got)
+	}
+}
+
+// TestDefineElement_OnOwnRoot — a widget root defines its own tags: their
+// components mount on that root and go away when it is closed.
+func TestDefineElement_OnOwnRoot(t *testing.T) {
+	widget := New(Options{})
+	cleaned := 0
+	err := widget.DefineElement("x-widget-counter", func(a *HostAttrs) Component {
+		return &TagCounter{start: a.Attr("start"), cleaned: &cleaned}
+	}, "start")
+	if err != nil {
+		t.Fatalf("DefineElement: %v", err)
+	}
+
+	doc := js.Global().Get("document")
+	box := doc.Call("createElement", "div")
+	box.Set("innerHTML", "<x-widget-counter start='5'></x-widget-counter>")
+	doc.Get("body").Call("appendChild", box)
+	defer box.Call("remove")
+	tag := box.Call("querySelector", "x-widget-counter")
+	if got := tag.Get("textContent").String(); got != "5" {
+		t.Fatalf("mounted text %q, want 5", got)
+	}
+
+	widget.Close()
+	if cleaned != 1 {
+		t.Errorf("closing the defining root ran %d cleanups, want 1", cleaned)
+	}
+	if got := tag.Get("textContent").String(); got != "" {
+		t.Errorf("tag content after Close: %q, want empty", got)
+	}
+}
@@ -0,0 +1,204 @@
+//go:build wasm
+
+package dom_test
+
+import (
+	"syscall/js"
+	"testing"
+
+	. "github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+)
+
+// OnceComp — a Once listener must fire a single time, and a Capture listener on
+// a parent must run before the child's own bubble-phase handler.
+type OnceComp struct {
+	Element
+	order  []string
+	clicks int
+}
+
+func (c *OnceComp) Render() *Element {
+	return NewElement("div").ID(c.GetID()).
+		OnWith("click", ListenerOptions{Capture: true}, func(e Event) { c.order = append(c.order, "parent-capture") }).
+		Child(NewElement("button").ID("once-btn").
+			OnWith("click", ListenerOptions{Once: true}, func(e Event) {
+				c.clicks++
+				c.order = append(c.order, "child")
+			}))
+}
+
+func TestOnWith_OnceAndCapture(t *testing.T) {
+	setupBindRoot()
+	comp := &OnceComp{}
+	comp.SetID("once-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	btn := js.Global().Get("document").Call("getElementById", "once-btn")
+	btn.Call("click")
+	btn.Call("click")
+
+	if comp.clicks != 1 {
+		t.Errorf("Once listener fired %d times, want 1", comp.clicks)
+	}
+	if len(comp.order) < 2 || comp.order[0] != "parent-capture" || comp.order[1] != "child" {
+		t.Errorf("capture order wrong: %v", comp.order)
+	}
+}
+
+// NestedComp — delegated handlers bubble innermost first, and StopPropagation
+// on the inner element keeps the outer one from running.
+type NestedComp struct {
+	Element
+	order []string
+	stop  bool
+}
+
+func (c *NestedComp) Render() *Element {
+	return NewElement("div").ID(c.GetID()).
+		On("click", func(e Event) { c.order = append(c.order, "outer") }).
+		Child(NewElement("span").ID("nested-inner").On("click", func(e Event) {
+			c.order = append(c.order, "inner")
+			if c.stop {
+				e.StopPropagation()
+			}
+		}))
+}
+
+func TestDelegation_BubblesAndStops(t *testing.T) {
+	setupBindRoot()
+	comp := &NestedComp{}
+	comp.SetID("nested-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	inner := js.Global().Get("document").Call("getElementById", "nested-inner")
+
+	inner.Call("click")
+	if len(comp.order) != 2 || comp.order[0] != "inner" || comp.order[1] != "outer" {
+		t.Errorf("bubble order wrong: %v", comp.order)
+	}
+
+	comp.order, comp.stop = nil, true
+	inner.Call("click")
+	if len(comp.order) != 1 || comp.order[0] != "inner" {
+		t.Errorf("StopPropagation did not stop the climb: %v", comp.order)
+	}
+}
+
+// KeysComp — keyboard accessors and CurrentTarget under delegation.
+type KeysComp struct {
+	Element
+	key, code, current string
+	shift              bool
+}
+
+func (c *KeysComp) Render() *Element {
+	return NewElement("div").ID(c.GetID()).
+		On("keydown", func(e Event) {
+			c.key, c.code, c.shift = e.Key(), e.Code(), e.ShiftKey()
+			if ref := e.CurrentTarget(); ref != nil {
+				c.current = ref.GetAttr("id")
+			}
+		}).
+		Child(NewElement("input").ID("keys-input"))
+}
+
+func TestEvent_KeyboardAccessors(t *testing.T) {
+	setupBindRoot()
+	comp := &KeysComp{}
+	comp.SetID("keys-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	init := js.Global().Get("Object").New()
+	init.Set("key", "A")
+	init.Set("code", "KeyA")
+	init.Set("shiftKey", true)
+	init.Set("bubbles", true)
+	evt := js.Global().Get("KeyboardEvent").New("keydown", init)
+	js.Global().Get("document").Call("getElementById", "keys-input").Call("dispatchEvent", evt)
+
+	if comp.key != "A" || comp.code != "KeyA" || !comp.shift {
+		t.Errorf("got key=%q code=%q shift=%v", comp.key, comp.code, comp.shift)
+	}
+	if comp.current != "keys-root" {
+		t.Errorf("CurrentTarget = %q, want the element On was called on", comp.current)
+	}
+}
+
+// TableComp hears its rows' custom "row-select" events through On, without
+// the rows knowing the table's id.
+type TableComp struct {
+	Element
+	selected string
+}
+
+func (c *TableComp) Render() *Element {
+	return NewElement("table").ID(c.GetID()).
+		On("row-select", func(e Event) { c.selected = e.DetailValue("row") }).
+		Child(NewElement("tr").ID("row-7").On("click", func(e Event) {
+			if ref := e.CurrentTarget(); ref != nil {
+				ref.DispatchKV("row-select", fmt.KeyValue{Key: "row", Value: "7"})
+			}
+		}))
+}
+
+func TestDispatch_CustomEventBubblesToAncestor(t *testing.T) {
+	setupBindRoot()
+	comp := &TableComp{}
+	comp.SetID("table-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	js.Global().Get("document").Call("getElementById", "row-7").Call("click")
+	if comp.selected != "7" {
+		t.Errorf("table heard row %q, want 7", comp.selected)
+	}
+}
+
+// PanelComp registers Esc in its own scope; a page-wide Esc exists too. With
+// focus inside the panel only the panel's handler runs.
+type PanelComp struct {
+	Element
+	closed *int
+}
+
+func (c *PanelComp) Init(ctx Ctx) {
+	Shortcut(ctx, "Esc", func(Event) { *c.closed++ })
+}
+
+func (c *PanelComp) Render() *Element {
+	return NewElement("div").ID(c.GetID()).Child(NewElement("button").ID("panel-btn"))
+}
+
+func TestShortcut_InnermostScopeWins(t *testing.T) {
+	setupBindRoot()
+	global, panel := 0, 0
+	GlobalShortcut(nil, "Esc", func(Event) { global++ })
+	comp := &PanelComp{closed: &panel}
+	comp.SetID("panel-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	doc := js.Global().Get("document")
+	press := func() {
+		init := js.Global().Get("Object").New()
+		init.Set("key", "Escape")
+		init.Set("bubbles", true)
+		doc.Get("activeElement").Call("dispatchEvent", js.Global().Get("KeyboardEvent").New("keydown", init))
+	}
+
+	doc.Call("getElementById", "panel-btn").Call("focus")
+	press()
+	if panel != 1 || global != 0 {
+		t.Errorf("focus in panel: panel=%d global=%d, want 1/0", panel, global)
+	}
+
+	doc.Call("getElementById", "panel-btn").Call("blur")
+	press()
+	if panel != 1 || global != 1 {
+		t.Errorf("focus outside: panel=%d global=%d, want 1/1", panel, global)
+	}
+}
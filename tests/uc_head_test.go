@@ -0,0 +1,68 @@
+//go:build wasm
+
+package dom_test
+
+import (
+	"syscall/js"
+	"testing"
+
+	. "github.com/tinywasm/dom"
+)
+
+type TitledComp struct {
+	Element
+	title *SignalString
+}
+
+func (c *TitledComp) Init(ctx Ctx) {
+	c.title = NewString("Detail")
+	Title(ctx, c.title)
+	Meta(ctx, "description", c.title)
+}
+func (c *TitledComp) Render() *Element { return NewElement("div").ID(c.GetID()) }
+
+type PlainComp struct{ Element }
+
+func (c *PlainComp) Render() *Element { return NewElement("div").ID(c.GetID()) }
+
+func metaContent(name string) string {
+	el := js.Global().Get("document").Get("head").Call("querySelector", "meta[name='"+name+"']")
+	if el.IsNull() {
+		return "<absent>"
+	}
+	return el.Call("getAttribute", "content").String()
+}
+
+func TestHeadBindings_FollowSignalAndRevertOnUnmount(t *testing.T) {
+	setupBindRoot()
+	doc := js.Global().Get("document")
+	doc.Set("title", "Page")
+
+	comp := &TitledComp{}
+	comp.SetID("titled-root")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if got := doc.Get("title").String(); got != "Detail" {
+		t.Fatalf("mounted: title want %q, got %q", "Detail", got)
+	}
+	if got := metaContent("description"); got != "Detail" {
+		t.Fatalf("mounted: description want %q, got %q", "Detail", got)
+	}
+
+	comp.title.Set("Edited")
+	if got := doc.Get("title").String(); got != "Edited" {
+		t.Errorf("after Set: title want %q, got %q", "Edited", got)
+	}
+
+	// Replacing the component unmounts it: the page gets its own head back.
+	if err := Render("bind-root", &PlainComp{}); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if got := doc.Get("title").String(); got != "Page" {
+		t.Errorf("after unmount: title want %q, got %q", "Page", got)
+	}
+	if got := metaContent("description"); got != "<absent>" {
+		t.Errorf("after unmount: description meta want removed, got %q", got)
+	}
+}
@@ -0,0 +1,92 @@
+//go:build wasm
+
+package dom_test
+
+import (
+	"syscall/js"
+	"testing"
+
+	. "github.com/tinywasm/dom"
+)
+
+// Toast counts its lifecycle so the stack operations can be checked to go
+// through the full mount and unmount paths.
+type Toast struct {
+	Element
+	text             string
+	mounted, cleaned int
+}
+
+func (c *Toast) Init(ctx Ctx)     { ctx.OnCleanup(func() { c.cleaned++ }) }
+func (c *Toast) Mounted()         { c.mounted++ }
+func (c *Toast) Render() *Element { return NewElement("li").Text(c.text) }
+
+func stackOrder() string {
+	out := ""
+	items := js.Global().Get("document").Call("getElementById", "toasts").Get("children")
+	for i := 0; i < items.Get("length").Int(); i++ {
+		out += items.Call("item", i).Get("textContent").String()
+	}
+	return out
+}
+
+func TestStack_PrependInsertReplaceUnmount(t *testing.T) {
+	setupBindRoot()
+	js.Global().Get("document").Call("getElementById", "bind-root").Set("innerHTML", "<ul id='toasts'></ul>")
+
+	a, b, c, d := &Toast{text: "a"}, &Toast{text: "b"}, &Toast{text: "c"}, &Toast{text: "d"}
+	if err := Append("toasts", a); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepend("toasts", b); err != nil {
+		t.Fatal(err)
+	}
+	if err := InsertBefore("toasts", a.GetID(), c); err != nil {
+		t.Fatal(err)
+	}
+	if got := stackOrder(); got != "bca" {
+		t.Errorf("order after Append/Prepend/InsertBefore: %q, want bca", got)
+	}
+	if a.mounted != 1 || b.mounted != 1 || c.mounted != 1 {
+		t.Error("every inserted component must get Mounted once")
+	}
+
+	if err := Replace(c, d); err != nil {
+		t.Fatal(err)
+	}
+	if got := stackOrder(); got != "bda" || c.cleaned != 1 || d.mounted != 1 {
+		t.Errorf("Replace: order %q cleaned=%d mounted=%d", got, c.cleaned, d.mounted)
+	}
+
+	Unmount(b)
+	if got := stackOrder(); got != "da" || b.cleaned != 1 {
+		t.Errorf("Unmount: order %q cleaned=%d", got, b.cleaned)
+	}
+
+	if err := InsertBefore("toasts", "missing", &Toast{text: "x"}); err == nil {
+		t.Error("InsertBefore a non-child must fail")
+	}
+}
+
+func TestReplace_UntrackedOldIsTrackedUnderDOMParent(t *testing.T) {
+	setupBindRoot()
+	js.Global().Get("document").Call("getElementById", "bind-root").Set("innerHTML", "<ul id='served'><li id='served-item'>s</li></ul>")
+
+	old := &Toast{text: "s"}
+	old.SetID("served-item")
+	fresh := &Toast{text: "n"}
+	if err := Replace(old, fresh); err != nil {
+		t.Fatal(err)
+	}
+	// A later Render over the DOM parent must unmount the replacement.
+	if err := Render("served", &Toast{text: "z"}); err != nil {
+		t.Fatal(err)
+	}
+	if fresh.cleaned != 1 {
+		t.Errorf("replacement cleaned=%d after Render over its DOM parent, want 1", fresh.cleaned)
+	}
+
+	if err := Replace(nil, &Toast{}); err == nil {
+		t.Error("Replace(nil, ...) must return an error")
+	}
+}
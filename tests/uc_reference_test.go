@@ -0,0 +1,107 @@
+//go:build wasm
+
+package dom_test
+
+import (
+	"syscall/js"
+	"testing"
+
+	. "github.com/tinywasm/dom"
+)
+
+type MenuComp struct {
+	Element
+}
+
+func (c *MenuComp) Render() *Element {
+	return NewElement("ul").ID(c.GetID()).Class("menu").Child(
+		NewElement("li").Class("item").Text("one"),
+		NewElement("li").Class("item", "active").Text("two"),
+	)
+}
+
+func TestReference_ClassesQueryAndNavigation(t *testing.T) {
+	setupBindRoot()
+	comp := &MenuComp{}
+	comp.SetID("menu")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	menu, ok := Get("menu")
+	if !ok {
+		t.Fatal("menu not found")
+	}
+
+	if n := len(menu.QueryAll("li.item")); n != 2 {
+		t.Errorf("QueryAll: %d items, want 2", n)
+	}
+	active, ok := menu.Query(".active")
+	if !ok {
+		t.Fatal("Query(.active) not found")
+	}
+	if parent, ok := active.Parent(); !ok || parent.GetAttr("id") != "menu" {
+		t.Error("Parent of an item must be the menu")
+	}
+	if n := len(menu.Children()); n != 2 {
+		t.Errorf("Children: %d, want 2", n)
+	}
+
+	active.RemoveClass("active")
+	if active.HasClass("active") {
+		t.Error("RemoveClass did not remove")
+	}
+	if !active.ToggleClass("active") || !active.HasClass("active") {
+		t.Error("ToggleClass must add a missing class and report true")
+	}
+	if _, ok := menu.Query(".missing"); ok {
+		t.Error("Query must report false when nothing matches")
+	}
+	if _, ok := menu.Query("li[[broken"); ok {
+		t.Error("an invalid selector must report false, not panic")
+	}
+	if n := len(menu.QueryAll(":not(")); n != 0 {
+		t.Errorf("QueryAll on an invalid selector: %d results, want 0", n)
+	}
+	if menu.ComputedStyle("display") != "block" {
+		t.Errorf("ComputedStyle(display) = %q, want block", menu.ComputedStyle("display"))
+	}
+	if r := menu.BoundingRect(); r.Right() < r.X {
+		t.Errorf("BoundingRect inconsistent: %+v", r)
+	}
+}
+
+// TestQuery_FindsForeignMarkup — markup this package never rendered (an
+// inline sprite, a third-party widget) is reachable by selector, and an id
+// found that way resolves through Get afterwards.
+func TestQuery_FindsForeignMarkup(t *testing.T) {
+	doc := js.Global().Get("document")
+	sprite := doc.Call("createElement", "div")
+	sprite.Set("innerHTML", "<svg><symbol id='icon-home'></symbol><symbol id='icon-user'></symbol></svg>")
+	doc.Get("body").Call("appendChild", sprite)
+	defer sprite.Call("remove")
+
+	if n := len(QueryAll("svg symbol")); n != 2 {
+		t.Errorf("QueryAll: %d symbols, want 2", n)
+	}
+	home, ok := Query("symbol#icon-home")
+	if !ok || home.GetAttr("id") != "icon-home" {
+		t.Fatal("Query did not find the sprite symbol")
+	}
+	if _, ok := Get("icon-home"); !ok {
+		t.Error("Get must resolve an id first reached through Query")
+	}
+	if _, ok := Query(".does-not-exist"); ok {
+		t.Error("Query must report false when nothing matches")
+	}
+}
+
+// TestQuery_InvalidSelectorFindsNothing — querySelector throws on a selector
+// it cannot parse; the program must survive and see no match.
+func TestQuery_InvalidSelectorFindsNothing(t *testing.T) {
+	if _, ok := Query("div[[broken"); ok {
+		t.Error("an invalid selector must report false")
+	}
+	if n := len(QueryAll(":not(")); n != 0 {
+		t.Errorf("QueryAll on an invalid selector: %d results, want 0", n)
+	}
+}
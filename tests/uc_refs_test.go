@@ -0,0 +1,127 @@
+//go:build wasm
+
+package dom_test
+
+import (
+	"testing"
+
+	. "github.com/tinywasm/dom"
+)
+
+// RefComp reaches its input through a Ref in Mounted — no manual ID.
+type RefComp struct {
+	Element
+	input       Ref
+	seenAtMount string
+}
+
+func (c *RefComp) Render() *Element {
+	return NewElement("div").Child(NewElement("input").Attr("name", "q").Ref(&c.input))
+}
+
+func (c *RefComp) Mounted() {
+	if in, ok := c.input.Get(); ok {
+		c.seenAtMount = in.GetAttr("name")
+	}
+}
+
+func TestRef_FilledAtMountClearedOnRemoval(t *testing.T) {
+	setupBindRoot()
+	a, b := &RefComp{}, &RefComp{}
+	if err := Render("bind-root", a); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if a.seenAtMount != "q" {
+		t.Errorf("Mounted saw %q through the ref, want q", a.seenAtMount)
+	}
+
+	// Replacing the content removes a's input: its ref must let go of it.
+	if err := Render("bind-root", b); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if _, ok := a.input.Get(); ok {
+		t.Error("ref still set after its element was removed")
+	}
+	if _, ok := b.input.Get(); !ok {
+		t.Error("second instance's ref not filled")
+	}
+}
+
+// WidgetList rows each host a "widget" started by OnMount and torn down by
+// the returned cleanup when the row goes away.
+type WidgetList struct {
+	Element
+	rows           *SignalNodes
+	live, disposed int
+}
+
+func (c *WidgetList) row(key string) *Element {
+	return NewElement("li").Key(key).OnMount(func(ref Reference) func() {
+		ref.SetAttr("data-widget", "on")
+		c.live++
+		return func() { c.disposed++ }
+	})
+}
+
+func (c *WidgetList) Render() *Element {
+	c.rows = NewNodes(c.row("a"))
+	return NewElement("ul").ID(c.GetID()).BindChildren(c.rows)
+}
+
+func TestOnMount_RunsForRowsAndCleansUp(t *testing.T) {
+	setupBindRoot()
+	comp := &WidgetList{}
+	comp.SetID("widgets")
+	if err := Render("bind-root", comp); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if comp.live != 1 {
+		t.Fatalf("first render: %d widgets started, want 1", comp.live)
+	}
+
+	comp.rows.Set([]*Element{comp.row("a"), comp.row("b")})
+	if comp.live != 2 || comp.disposed != 0 {
+		t.Errorf("after insert: live=%d disposed=%d, want 2/0", comp.live, comp.disposed)
+	}
+
+	comp.rows.Set([]*Element{comp.row("b")})
+	if comp.disposed != 1 {
+		t.Errorf("after removing a row: disposed=%d, want 1", comp.disposed)
+	}
+}
+
+// RowRefs keeps a Ref to a row of a list that is not the component's root.
+type RowRefs struct {
+	Element
+	rows  *SignalNodes
+	first Ref
+}
+
+func (c *RowRefs) Render() *Element {
+	c.rows = NewNodes(NewElement("li").Key("r1").Ref(&c.first))
+	return NewElement("section").Child(NewElement("ul").BindChildren(c.rows))
+}
+
+func TestRef_RowRefClearedWithRowAndWithComponent(t *testing.T) {
+	setupBindRoot()
+	c := &RowRefs{}
+	if err := Render("bind-root", c); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if _, ok := c.first.Get(); !ok {
+		t.Fatal("row ref not filled")
+	}
+	c.rows.Set(nil)
+	if _, ok := c.first.Get(); ok {
+		t.Error("row ref still set after the row left the list")
+	}
+
+	c.rows.Set([]*Element{NewElement("li").Key("r2").Ref(&c.first)})
+	if _, ok := c.first.Get(); !ok {
+		t.Fatal("ref of the inserted row not filled")
+	}
+	Unmount(c)
+	if _, ok := c.first.Get(); ok {
+		t.Error("row ref still set after its component was unmounted")
+	}
+}
@@ -0,0 +1,97 @@
+//go:build wasm
+
+package dom_test
+
+import (
+	"syscall/js"
+	"testing"
+
+	. "github.com/tinywasm/dom"
+)
+
+type Widget struct {
+	Element
+	label   *SignalString
+	cleaned int
+}
+
+func (c *Widget) Init(ctx Ctx) {
+	c.label = NewString("ready")
+	ctx.OnCleanup(func() { c.cleaned++ })
+}
+func (c *Widget) Render() *Element { return NewElement("p").BindText(c.label) }
+
+// TestRoots_AreIsolated — a host app and an embedded widget, each on its own
+// root: re-rendering the host's mount point does not unmount the widget,
+// and ids stay unique across both.
+func TestRoots_AreIsolated(t *testing.T) {
+	doc := js.Global().Get("document")
+	host := doc.Call("createElement", "div")
+	host.Set("innerHTML", "<div id='host-app'></div><div id='widget-slot'></div>")
+	doc.Get("body").Call("appendChild", host)
+	defer host.Call("remove")
+
+	hostRoot, widgetRoot := New(Options{}), New(Options{DevMode: true})
+
+	a, w := &Widget{}, &Widget{}
+	if err := hostRoot.Render("host-app", a); err != nil {
+		t.Fatal(err)
+	}
+	if err := widgetRoot.Render("widget-slot", w); err != nil {
+		t.Fatal(err)
+	}
+	if a.GetID() == w.GetID() {
+		t.Fatalf("two roots minted the same id %q", a.GetID())
+	}
+
+	// Re-rendering the host cleans up the host's component only.
+	if err := hostRoot.Render("host-app", &Widget{}); err != nil {
+		t.Fatal(err)
+	}
+	if a.cleaned != 1 || w.cleaned != 0 {
+		t.Errorf("cleanups: host=%d widget=%d, want 1/0", a.cleaned, w.cleaned)
+	}
+
+	w.label.Set("still live")
+	if got := doc.Call("getElementById", w.GetID()).Get("textContent").String(); got != "still live" {
+		t.Errorf("widget stopped reacting: %q", got)
+	}
+	if _, ok := widgetRoot.Get(w.GetID()); !ok {
+		t.Error("widget root lost its element")
+	}
+}
+
+// TestRoots_CloseTearsDownTheRoot — closing a micro-frontend's root unmounts
+// what it mounted and leaves the other root alone.
+func TestRoots_CloseTearsDownTheRoot(t *testing.T) {
+	doc := js.Global().Get("document")
+	host := doc.Call("createElement", "div")
+	host.Set("innerHTML", "<div id='close-host'></div><div id='close-widget'></div>")
+	doc.Get("body").Call("appendChild", host)
+	defer host.Call("remove")
+
+	hostRoot, widgetRoot := New(Options{}), New(Options{})
+	a, w := &Widget{}, &Widget{}
+	if err := hostRoot.Render("close-host", a); err != nil {
+		t.Fatal(err)
+	}
+	if err := widgetRoot.Render("close-widget", w); err != nil {
+		t.Fatal(err)
+	}
+
+	widgetRoot.Close()
+	if w.cleaned != 1 || a.cleaned != 0 {
+		t.Errorf("cleanups after Close: widget=%d host=%d, want 1/0", w.cleaned, a.cleaned)
+	}
+	if !doc.Call("getElementById", w.GetID()).IsNull() {
+		t.Error("Close must remove the root's markup")
+	}
+	if err := widgetRoot.Render("close-widget", &Widget{}); err == nil {
+		t.Error("Render on a closed root must return an error")
+	}
+
+	a.label.Set("host still live")
+	if got := doc.Call("getElementById", a.GetID()).Get("textContent").String(); got != "host still live" {
+		t.Errorf("host root stopped reacting: %q", got)
+	}
+}
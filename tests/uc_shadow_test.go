@@ -0,0 +1,127 @@
+//go:build wasm
+
+package dom_test
+
+import (
+	"syscall/js"
+	"testing"
+
+	. "github.com/tinywasm/dom"
+)
+
+type ShadowWidget struct {
+	Element
+	label  *SignalString
+	clicks int
+}
+
+func (c *ShadowWidget) Init(_ Ctx) { c.label = NewString("idle") }
+func (c *ShadowWidget) Render() *Element {
+	return NewElement("div").Child(
+		NewElement("p").ID("shadow-label").BindText(c.label),
+		NewElement("button").ID("shadow-btn").On("click", func(Event) {
+			c.clicks++
+			c.label.Set("clicked")
+		}),
+	)
+}
+
+// TestShadow_ClosedRootRendersWiresAndIsolatesCSS — a widget in a closed
+// shadow root still gets its handlers, bindings and Get, and the host page's
+// CSS does not reach it.
+func TestShadow_ClosedRootRendersWiresAndIsolatesCSS(t *testing.T) {
+	doc := js.Global().Get("document")
+	host := doc.Call("createElement", "div")
+	host.Set("id", "shadow-host")
+	doc.Get("body").Call("appendChild", host)
+	pageCSS := doc.Call("createElement", "style")
+	pageCSS.Set("textContent", "p { color: rgb(255, 0, 0); }")
+	doc.Get("head").Call("appendChild", pageCSS)
+	defer host.Call("remove")
+	defer pageCSS.Call("remove")
+
+	mount, err := AttachShadow("shadow-host", ShadowOptions{
+		Closed: true,
+		Styles: []string{"p { color: rgb(0, 0, 255); }"},
+	})
+	if err != nil {
+		t.Fatalf("AttachShadow: %v", err)
+	}
+	w := &ShadowWidget{}
+	if err := Render(mount, w); err != nil {
+		t.Fatalf("Render into shadow: %v", err)
+	}
+	if !host.Get("shadowRoot").IsNull() {
+		t.Error("closed root must not be reachable through host.shadowRoot")
+	}
+
+	btn, ok := Get("shadow-btn")
+	if !ok {
+		t.Fatal("Get must resolve inside the shadow root")
+	}
+	label, _ := Get("shadow-label")
+	if got := label.ComputedStyle("color"); got != "rgb(0, 0, 255)" {
+		t.Errorf("shadow text color %q: host CSS leaked in or styles not adopted", got)
+	}
+
+	if ref, ok := Query("#shadow-btn"); ok && ref != nil {
+		t.Error("document Query must not see into a closed shadow root")
+	}
+	btn.Dispatch("click", "") // delegated from the shadow root's own listener
+	if w.clicks != 1 {
+		t.Errorf("handler inside the shadow root ran %d times, want 1", w.clicks)
+	}
+}
+
+// TestShadow_AttachErrorsInsteadOfPanicking — a second root on the same host
+// and a tag that cannot host one are errors, not browser exceptions.
+func TestShadow_AttachErrorsInsteadOfPanicking(t *testing.T) {
+	doc := js.Global().Get("document")
+	host := doc.Call("createElement", "section")
+	host.Set("id", "shadow-twice")
+	input := doc.Call("createElement", "input")
+	input.Set("id", "shadow-input")
+	doc.Get("body").Call("appendChild", host)
+	doc.Get("body").Call("appendChild", input)
+	defer host.Call("remove")
+	defer input.Call("remove")
+
+	if _, err := AttachShadow("shadow-twice", ShadowOptions{Closed: true}); err != nil {
+		t.Fatalf("first AttachShadow: %v", err)
+	}
+	if _, err := AttachShadow("shadow-twice", ShadowOptions{}); err == nil {
+		t.Error("a second AttachShadow on the same host must return an error")
+	}
+	if _, err := AttachShadow("shadow-input", ShadowOptions{}); err == nil {
+		t.Error("AttachShadow on an <input> must return an error")
+	}
+}
+
+// TestShadow_ClosedRootSurvivesHostReinsertion — looking an id up while the
+// host is detached must not forget the root: once the host is back, Get
+// reaches inside again.
+func TestShadow_ClosedRootSurvivesHostReinsertion(t *testing.T) {
+	doc := js.Global().Get("document")
+	host := doc.Call("createElement", "div")
+	host.Set("id", "shadow-moved")
+	doc.Get("body").Call("appendChild", host)
+	defer host.Call("remove")
+
+	mount, err := AttachShadow("shadow-moved", ShadowOptions{Closed: true})
+	if err != nil {
+		t.Fatalf("AttachShadow: %v", err)
+	}
+	p := NewElement("p").ID("moved-label").Text("here")
+	if err := Render(mount, p); err != nil {
+		t.Fatalf("Render into shadow: %v", err)
+	}
+
+	host.Call("remove")
+	if _, ok := Get("moved-label"); ok {
+		t.Error("an element under a detached host must not be found")
+	}
+	doc.Get("body").Call("appendChild", host)
+	if _, ok := Get("moved-label"); !ok {
+		t.Error("Get must reach the closed root again once its host is back")
+	}
+}
@@ -0,0 +1,83 @@
+package dom
+
+// Topic is an in-process publish/subscribe channel between components that do
+// not own each other: a toolbar telling a list to refresh, a row telling its
+// table it was selected. T is the payload every publisher sends and every
+// subscriber receives, so a mismatched payload — like a misspelled topic — is
+// a compile error instead of a message nobody understands. Declare one as a
+// package variable next to the component that publishes it:
+//
+//	type Refresh struct{ Filter string }
+//
+//	var ListRefresh = dom.NewTopic[Refresh]("list.refresh")
+//
+//	ListRefresh.Publish(Refresh{Filter: "open"})
+//
+//	func (l *List) Init(ctx dom.Ctx) {
+//		ListRefresh.Subscribe(ctx, func(r Refresh) { l.reload(r.Filter) })
+//	}
+//
+// Delivery is synchronous and in subscription order. Unlike a custom DOM event
+// it does not need the two components to be ancestor and descendant.
+type Topic[T any] struct {
+	name   string
+	subs   []topicSub[T]
+	nextID uint64
+}
+
+type topicSub[T any] struct {
+	id uint64
+	fn func(T)
+}
+
+// NewTopic returns a topic carrying T. The name is only for logs and debugging.
+func NewTopic[T any](name string) *Topic[T] { return &Topic[T]{name: name} }
+
+// Name returns the name the topic was created with.
+func (t *Topic[T]) Name() string { return t.name }
+
+// Publish delivers payload to every current subscriber. A subscriber added by
+// a handler during delivery first hears the next Publish; one removed during
+// delivery — unsubscribed, or its component unmounted — is not called again,
+// even in this one.
+func (t *Topic[T]) Publish(payload T) {
+	snapshot := make([]topicSub[T], len(t.subs))
+	copy(snapshot, t.subs)
+	for _, s := range snapshot {
+		if t.subscribed(s.id) {
+			s.fn(payload)
+		}
+	}
+}
+
+// subscribed reports whether the subscription id is still registered.
+func (t *Topic[T]) subscribed(id uint64) bool {
+	for _, s := range t.subs {
+		if s.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribe registers fn. The subscription belongs to ctx — the Ctx handed to
+// the subscriber's Init — and is removed when that component unmounts, so a
+// list that is gone never reloads. With a nil ctx it lives until the returned
+// function is called.
+func (t *Topic[T]) Subscribe(ctx Ctx, fn func(T)) (unsubscribe func()) {
+	t.nextID++
+	id := t.nextID
+	t.subs = append(t.subs, topicSub[T]{id: id, fn: fn})
+	unsubscribe = func() {
+		for i, s := range t.subs {
+			if s.id == id {
+				t.subs = append(t.subs[:i], t.subs[i+1:]...)
+				return
+			}
+		}
+	}
+	if ctx != nil {
+		ctx.OnCleanup(unsubscribe)
+	}
+	return unsubscribe
+}
@@ -0,0 +1,69 @@
+package dom
+
+import "testing"
+
+// testCtx collects OnCleanup callbacks so a test can "unmount".
+type testCtx struct{ cleanups []func() }
+
+func (c *testCtx) OnCleanup(fn func()) { c.cleanups = append(c.cleanups, fn) }
+func (c *testCtx) unmount() {
+	for _, fn := range c.cleanups {
+		fn()
+	}
+}
+
+type refreshMsg struct{ filter string }
+
+func TestTopicDeliversUntilOwnerUnmounts(t *testing.T) {
+	refresh := NewTopic[refreshMsg]("list.refresh")
+	ctx := &testCtx{}
+	var got []string
+	refresh.Subscribe(ctx, func(m refreshMsg) { got = append(got, m.filter) })
+
+	refresh.Publish(refreshMsg{filter: "open"})
+	refresh.Publish(refreshMsg{})
+	if len(got) != 2 || got[0] != "open" || got[1] != "" {
+		t.Fatalf("deliveries: %q", got)
+	}
+
+	ctx.unmount()
+	refresh.Publish(refreshMsg{filter: "closed"})
+	if len(got) != 2 {
+		t.Errorf("subscriber still called after its component unmounted: %q", got)
+	}
+}
+
+func TestTopicSubscribeDuringPublish(t *testing.T) {
+	tp := NewTopic[struct{}]("t")
+	calls := 0
+	var unsub func()
+	unsub = tp.Subscribe(nil, func(struct{}) {
+		calls++
+		unsub()
+		tp.Subscribe(nil, func(struct{}) { calls += 10 })
+	})
+	tp.Publish(struct{}{})
+	if calls != 1 {
+		t.Errorf("a subscriber added during delivery must wait for the next Publish, calls=%d", calls)
+	}
+	tp.Publish(struct{}{})
+	if calls != 11 {
+		t.Errorf("calls=%d, want 11", calls)
+	}
+}
+
+func TestTopicSkipsSubscribersRemovedDuringPublish(t *testing.T) {
+	selected := NewTopic[int]("row.selected")
+	list := &testCtx{}
+	var got []string
+	selected.Subscribe(nil, func(int) {
+		got = append(got, "table")
+		list.unmount() // the table's handler tears the list down
+	})
+	selected.Subscribe(list, func(int) { got = append(got, "list") })
+
+	selected.Publish(3)
+	if len(got) != 1 || got[0] != "table" {
+		t.Errorf("a subscriber unmounted earlier in the same Publish must not run: %q", got)
+	}
+}
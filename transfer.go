@@ -0,0 +1,56 @@
+package dom
+
+// statePayloadID is the id of the <script type="application/json"> block that
+// carries the initial signal state from the server to the client.
+const statePayloadID = "dom-state"
+
+// transfer is one signal registered for server→client state transfer.
+type transfer struct {
+	name   string
+	signal subscribable
+}
+
+// TransferString registers s under name for SSR state transfer and returns it,
+// so it wraps the constructor in Init:
+//
+//	c.count = dom.TransferString(ctx, "count", dom.NewString("0"))
+//
+// On the backend the signal's value at render time is written into the
+// hydration payload of the Document whose render handed out ctx; with any
+// other ctx the server ignores it. In WASM the payload is read back
+// and, when it carries name, s is set to the server's value before first
+// render — no flash of the default, no refetch. The value is read once: a
+// later Init under the same name (a remount) keeps the signal it was given.
+//
+// Names are page-global: two components transferring the same name read the
+// same value. There is no TransferNodes: rows are built from data, so transfer
+// the data (as a string) and rebuild the rows from it in Init.
+func TransferString(ctx Ctx, name string, s *SignalString) *SignalString {
+	if v, ok := registerTransfer(ctx, name, s); ok {
+		s.Set(v)
+	}
+	return s
+}
+
+// TransferBool is TransferString for a SignalBool. The payload carries
+// "true"/"false".
+func TransferBool(ctx Ctx, name string, s *SignalBool) *SignalBool {
+	if v, ok := registerTransfer(ctx, name, s); ok {
+		s.Set(v == "true")
+	}
+	return s
+}
+
+// transferValue reads a registered signal as the string the payload carries.
+func transferValue(sig subscribable) string {
+	switch s := sig.(type) {
+	case *SignalString:
+		return s.Get()
+	case *SignalBool:
+		if s.Get() {
+			return "true"
+		}
+		return "false"
+	}
+	return ""
+}
@@ -0,0 +1,21 @@
+//go:build !wasm
+
+package dom
+
+// registerTransfer records the signal on the Document ctx belongs to. Without
+// a page there is nothing to carry it, so it is dropped. The server has no
+// payload to read from, so it never reports a value.
+func registerTransfer(ctx Ctx, name string, sig subscribable) (string, bool) {
+	p := pageOf(ctx)
+	if p == nil {
+		return "", false
+	}
+	for i, t := range p.transfers {
+		if t.name == name {
+			p.transfers[i].signal = sig
+			return "", false
+		}
+	}
+	p.transfers = append(p.transfers, transfer{name: name, signal: sig})
+	return "", false
+}
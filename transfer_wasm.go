@@ -0,0 +1,35 @@
+//go:build wasm
+
+package dom
+
+import "syscall/js"
+
+// transferState caches the parsed hydration payload; transferLoaded records
+// that the lookup already happened, so a page without a payload is probed once.
+var (
+	transferState  js.Value
+	transferLoaded bool
+)
+
+// registerTransfer looks name up in the server's payload and uses it up: the
+// server's value describes the first render only. A component that unmounts
+// and mounts again runs Init again, and by then the snapshot is stale — its
+// signal keeps the constructor's value like any other.
+func registerTransfer(_ Ctx, name string, _ subscribable) (string, bool) {
+	if !transferLoaded {
+		transferLoaded = true
+		el := js.Global().Get("document").Call("getElementById", statePayloadID)
+		if !el.IsNull() && !el.IsUndefined() {
+			transferState = js.Global().Get("JSON").Call("parse", el.Get("textContent"))
+		}
+	}
+	if transferState.IsUndefined() || transferState.IsNull() {
+		return "", false
+	}
+	v := transferState.Get(name)
+	if v.Type() != js.TypeString {
+		return "", false
+	}
+	transferState.Delete(name)
+	return v.String(), true
+}